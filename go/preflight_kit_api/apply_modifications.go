@@ -0,0 +1,171 @@
+package preflight_kit_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PropertyChange is the effect of a modification on a property of an experiment execution.
+type PropertyChange struct {
+	PropertyKey string
+	// Before is the value before the modification, nil if the property didn't exist.
+	Before any
+	After  any
+}
+
+// PropertyConflictError reports a modification which can't be applied to the properties, e.g. adding a value to a
+// property which isn't a list.
+type PropertyConflictError struct {
+	// Index of the modification in the applied list.
+	Index       int
+	PropertyKey string
+	Reason      string
+}
+
+func (e *PropertyConflictError) Error() string {
+	return fmt.Sprintf("modification %d of property %q: %s", e.Index, e.PropertyKey, e.Reason)
+}
+
+// ApplyModifications applies the modifications to the properties of the execution like the platform does, to preview
+// them in tests, and returns the changes in order. A property key like "a.b" refers to the property "a.b", if it
+// exists, and to the property "b" nested in "a" otherwise. Missing parents are created.
+//
+// set_property_value replaces a value of the same JSON type, add_value_to_list_property appends to a list, which is
+// created if missing. Any other type makes the modification conflict. If a modification is invalid or conflicts, all
+// errors are returned and the properties are left unchanged.
+func ApplyModifications(execution *ExperimentExecutionAO, modifications ExecutionModifications) ([]PropertyChange, error) {
+	properties := map[string]any{}
+	if execution.Properties != nil && *execution.Properties != nil {
+		// works on a copy, which is only stored if all modifications apply
+		normalized, err := normalize(*execution.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("properties can't be encoded: %w", err)
+		}
+		properties = normalized.(map[string]any)
+	}
+
+	var changes []PropertyChange
+	var problems []error
+	for i, modification := range modifications {
+		change, err := applyModification(properties, modification)
+		var conflict *PropertyConflictError
+		if errors.As(err, &conflict) {
+			conflict.Index = i
+		} else if err != nil {
+			err = fmt.Errorf("modification %d: %w", i, err)
+		}
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		changes = append(changes, change)
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+
+	execution.Properties = &properties
+	return changes, nil
+}
+
+func applyModification(properties map[string]any, modification ExecutionModification) (PropertyChange, error) {
+	var change PropertyChange
+	err := modification.Switch(ExecutionModificationCases{
+		SetPropertyValue: func(m ExecutionModificationSetPropertyValue) error {
+			parent, key, err := resolveProperty(properties, m.PropertyKey)
+			if err != nil {
+				return err
+			}
+			value, err := normalize(m.Value)
+			if err != nil {
+				return fmt.Errorf("value of property %q can't be encoded: %w", m.PropertyKey, err)
+			}
+			before, exists := parent[key]
+			if exists && before != nil && jsonType(before) != jsonType(value) {
+				return &PropertyConflictError{PropertyKey: m.PropertyKey, Reason: fmt.Sprintf("can't set %s value of %s property", jsonType(value), jsonType(before))}
+			}
+			parent[key] = value
+			change = PropertyChange{PropertyKey: m.PropertyKey, Before: before, After: value}
+			return nil
+		},
+		AddValueToListProperty: func(m ExecutionModificationAddValueToListProperty) error {
+			parent, key, err := resolveProperty(properties, m.PropertyKey)
+			if err != nil {
+				return err
+			}
+			value, err := normalize(m.Value)
+			if err != nil {
+				return fmt.Errorf("value of property %q can't be encoded: %w", m.PropertyKey, err)
+			}
+			before := parent[key]
+			var list []any
+			if before != nil {
+				existing, ok := before.([]any)
+				if !ok {
+					return &PropertyConflictError{PropertyKey: m.PropertyKey, Reason: fmt.Sprintf("can't add a value to %s property", jsonType(before))}
+				}
+				list = append(list, existing...)
+			}
+			parent[key] = append(list, value)
+			change = PropertyChange{PropertyKey: m.PropertyKey, Before: before, After: parent[key]}
+			return nil
+		},
+	})
+	return change, err
+}
+
+// resolveProperty returns the map holding the property and its key within it, creating missing parents.
+func resolveProperty(properties map[string]any, propertyKey string) (map[string]any, string, error) {
+	parent := properties
+	remaining := propertyKey
+	for {
+		if _, exists := parent[remaining]; exists {
+			return parent, remaining, nil
+		}
+		head, tail, nested := strings.Cut(remaining, ".")
+		if !nested || head == "" || tail == "" {
+			return parent, remaining, nil
+		}
+		child, exists := parent[head]
+		if !exists || child == nil {
+			child = map[string]any{}
+			parent[head] = child
+		}
+		childMap, ok := child.(map[string]any)
+		if !ok {
+			return nil, "", &PropertyConflictError{PropertyKey: propertyKey, Reason: fmt.Sprintf("%q is a %s, not an object", head, jsonType(child))}
+		}
+		parent, remaining = childMap, tail
+	}
+}
+
+// normalize converts a value to its JSON representation, e.g. ints to float64, like the platform receives it.
+func normalize(value any) (any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = json.Unmarshal(b, &normalized)
+	return normalized, err
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
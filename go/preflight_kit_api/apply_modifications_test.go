@@ -0,0 +1,109 @@
+package preflight_kit_api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func modifications(t *testing.T, create ...func() (ExecutionModification, error)) ExecutionModifications {
+	var result ExecutionModifications
+	for _, c := range create {
+		modification, err := c()
+		require.NoError(t, err)
+		result = append(result, modification)
+	}
+	return result
+}
+
+func set(propertyKey string, value any) func() (ExecutionModification, error) {
+	return func() (ExecutionModification, error) { return NewSetPropertyValue(propertyKey, value) }
+}
+
+func add(propertyKey string, value any) func() (ExecutionModification, error) {
+	return func() (ExecutionModification, error) { return NewAddValueToListProperty(propertyKey, value) }
+}
+
+func TestApplyModifications(t *testing.T) {
+	execution := ExperimentExecutionAO{Properties: &map[string]any{
+		"ticket":       "OPS-1",
+		"approvers":    []any{"alice"},
+		"change.id":    "flat",
+		"deployment":   map[string]any{"replicas": 3},
+		"release-note": nil,
+	}}
+
+	changes, err := ApplyModifications(&execution, modifications(t,
+		set("ticket", "OPS-2"),
+		add("approvers", "bob"),
+		add("reviewers", "carol"),
+		set("change.id", "still flat"),
+		set("deployment.replicas", 5),
+		set("deployment.strategy.type", "RollingUpdate"),
+		set("release-note", "fixed"),
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, []PropertyChange{
+		{PropertyKey: "ticket", Before: "OPS-1", After: "OPS-2"},
+		{PropertyKey: "approvers", Before: []any{"alice"}, After: []any{"alice", "bob"}},
+		{PropertyKey: "reviewers", Before: nil, After: []any{"carol"}},
+		{PropertyKey: "change.id", Before: "flat", After: "still flat"},
+		{PropertyKey: "deployment.replicas", Before: float64(3), After: float64(5)},
+		{PropertyKey: "deployment.strategy.type", Before: nil, After: "RollingUpdate"},
+		{PropertyKey: "release-note", Before: nil, After: "fixed"},
+	}, changes)
+	assert.Equal(t, map[string]any{
+		"ticket":       "OPS-2",
+		"approvers":    []any{"alice", "bob"},
+		"reviewers":    []any{"carol"},
+		"change.id":    "still flat",
+		"deployment":   map[string]any{"replicas": float64(5), "strategy": map[string]any{"type": "RollingUpdate"}},
+		"release-note": "fixed",
+	}, *execution.Properties)
+}
+
+func TestApplyModifications_without_properties(t *testing.T) {
+	execution := ExperimentExecutionAO{}
+
+	changes, err := ApplyModifications(&execution, modifications(t, set("a.b", true)))
+	require.NoError(t, err)
+	assert.Equal(t, []PropertyChange{{PropertyKey: "a.b", After: true}}, changes)
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": true}}, *execution.Properties)
+}
+
+func TestApplyModifications_conflicts(t *testing.T) {
+	original := map[string]any{
+		"ticket":    "OPS-1",
+		"approvers": []any{"alice"},
+	}
+	execution := ExperimentExecutionAO{Properties: &original}
+
+	_, err := ApplyModifications(&execution, modifications(t,
+		set("ticket", 42),
+		add("ticket", "OPS-2"),
+		set("approvers", "bob"),
+		set("ticket.id", "OPS-3"),
+		set("valid", "ignored"),
+	))
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `modification 0 of property "ticket": can't set number value of string property`)
+	assert.ErrorContains(t, err, `modification 1 of property "ticket": can't add a value to string property`)
+	assert.ErrorContains(t, err, `modification 2 of property "approvers": can't set string value of array property`)
+	assert.ErrorContains(t, err, `modification 3 of property "ticket.id": "ticket" is a string, not an object`)
+	var conflict *PropertyConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, map[string]any{"ticket": "OPS-1", "approvers": []any{"alice"}}, *execution.Properties, "the properties are unchanged")
+}
+
+func TestApplyModifications_rejects_invalid_modifications(t *testing.T) {
+	var unknown ExecutionModification
+	require.NoError(t, unknown.UnmarshalJSON([]byte(`{"type": "remove_property", "propertyKey": "ticket"}`)))
+	execution := ExperimentExecutionAO{}
+
+	_, err := ApplyModifications(&execution, ExecutionModifications{unknown})
+	assert.EqualError(t, err, "modification 0: unknown discriminator value: remove_property")
+	assert.Nil(t, execution.Properties)
+}
@@ -0,0 +1,120 @@
+package preflight_kit_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// summaryTextMaxLength is the maxLength of the summary text in the spec.
+const summaryTextMaxLength = 512
+
+// ModificationConflict reports a modification contradicting an earlier one of the same execution.
+type ModificationConflict struct {
+	PropertyKey string
+	Reason      string
+}
+
+func (c ModificationConflict) String() string {
+	return fmt.Sprintf("%s: %s", c.PropertyKey, c.Reason)
+}
+
+// ModificationMerger accumulates the modifications an execution returns from Start, Status and Cancel. The zero value
+// is ready to use.
+type ModificationMerger struct {
+	values map[string]any
+	lists  map[string][]any
+}
+
+// Merge adds the modifications of a result to the accumulated ones. It returns the modifications without list
+// additions of values which have been added before, and the conflicts with earlier modifications: setting another
+// value than before, or both setting and adding to the same property. Invalid modifications fail the merge.
+func (m *ModificationMerger) Merge(modifications ExecutionModifications) (ExecutionModifications, []ModificationConflict, error) {
+	if m.values == nil {
+		m.values = make(map[string]any)
+		m.lists = make(map[string][]any)
+	}
+
+	merged := make(ExecutionModifications, 0, len(modifications))
+	var conflicts []ModificationConflict
+	for i, modification := range modifications {
+		duplicate := false
+		err := modification.Switch(ExecutionModificationCases{
+			SetPropertyValue: func(s ExecutionModificationSetPropertyValue) error {
+				value, err := normalize(s.Value)
+				if err != nil {
+					return fmt.Errorf("value of property %q can't be encoded: %w", s.PropertyKey, err)
+				}
+				if _, added := m.lists[s.PropertyKey]; added {
+					conflicts = append(conflicts, ModificationConflict{PropertyKey: s.PropertyKey, Reason: "set after values have been added to it"})
+				} else if previous, set := m.values[s.PropertyKey]; set && !reflect.DeepEqual(previous, value) {
+					conflicts = append(conflicts, ModificationConflict{PropertyKey: s.PropertyKey, Reason: fmt.Sprintf("set to %s after %s", compactJson(value), compactJson(previous))})
+				}
+				m.values[s.PropertyKey] = value
+				return nil
+			},
+			AddValueToListProperty: func(a ExecutionModificationAddValueToListProperty) error {
+				value, err := normalize(a.Value)
+				if err != nil {
+					return fmt.Errorf("value of property %q can't be encoded: %w", a.PropertyKey, err)
+				}
+				if _, set := m.values[a.PropertyKey]; set {
+					conflicts = append(conflicts, ModificationConflict{PropertyKey: a.PropertyKey, Reason: "values added after it has been set"})
+				}
+				for _, existing := range m.lists[a.PropertyKey] {
+					if reflect.DeepEqual(existing, value) {
+						duplicate = true
+						return nil
+					}
+				}
+				m.lists[a.PropertyKey] = append(m.lists[a.PropertyKey], value)
+				return nil
+			},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("modification %d: %w", i, err)
+		}
+		if !duplicate {
+			merged = append(merged, modification)
+		}
+	}
+	return merged, conflicts, nil
+}
+
+// ConflictSummary adds the conflicts to the summary of a result and raises it to a warning. A nil summary is created,
+// and without conflicts the summary is returned as is.
+func ConflictSummary(summary *Summary, conflicts []ModificationConflict) *Summary {
+	if len(conflicts) == 0 {
+		return summary
+	}
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		descriptions = append(descriptions, conflict.String())
+	}
+	text := "Conflicting modifications: " + strings.Join(descriptions, "; ")
+	if summary != nil && summary.Text != "" {
+		text = summary.Text + " " + text
+	}
+	return &Summary{Level: SummaryLevelWarning, Text: truncateSummaryText(text)}
+}
+
+func truncateSummaryText(text string) string {
+	if len(text) <= summaryTextMaxLength {
+		return text
+	}
+	cut := summaryTextMaxLength - len("…")
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "…"
+}
+
+func compactJson(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
@@ -0,0 +1,79 @@
+package preflight_kit_api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModificationMerger(t *testing.T) {
+	var merger ModificationMerger
+
+	merged, conflicts, err := merger.Merge(modifications(t,
+		set("ticket", "OPS-1"),
+		add("approvers", "alice"),
+		add("approvers", "alice"),
+	))
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, modifications(t, set("ticket", "OPS-1"), add("approvers", "alice")), merged, "duplicate additions are dropped")
+
+	merged, conflicts, err = merger.Merge(modifications(t,
+		set("ticket", "OPS-1"),
+		add("approvers", "alice"),
+		add("approvers", "bob"),
+		add("reviewers", map[string]any{"name": "carol", "level": 1}),
+	))
+	require.NoError(t, err)
+	assert.Empty(t, conflicts, "setting the same value again doesn't conflict")
+	assert.Equal(t, modifications(t,
+		set("ticket", "OPS-1"),
+		add("approvers", "bob"),
+		add("reviewers", map[string]any{"name": "carol", "level": 1}),
+	), merged)
+
+	merged, conflicts, err = merger.Merge(modifications(t,
+		add("reviewers", map[string]any{"level": 1.0, "name": "carol"}),
+		set("ticket", "OPS-2"),
+		set("approvers", "dave"),
+		add("ticket", "OPS-3"),
+	))
+	require.NoError(t, err)
+	assert.Equal(t, modifications(t, set("ticket", "OPS-2"), set("approvers", "dave"), add("ticket", "OPS-3")), merged, "values are compared by their JSON")
+	assert.Equal(t, []ModificationConflict{
+		{PropertyKey: "ticket", Reason: `set to "OPS-2" after "OPS-1"`},
+		{PropertyKey: "approvers", Reason: "set after values have been added to it"},
+		{PropertyKey: "ticket", Reason: "values added after it has been set"},
+	}, conflicts)
+}
+
+func TestModificationMerger_invalid(t *testing.T) {
+	var merger ModificationMerger
+	_, _, err := merger.Merge(ExecutionModifications{{}})
+	assert.ErrorContains(t, err, "modification 0:")
+}
+
+func TestConflictSummary(t *testing.T) {
+	assert.Nil(t, ConflictSummary(nil, nil))
+	info := &Summary{Level: SummaryLevelInfo, Text: "Approved."}
+	assert.Same(t, info, ConflictSummary(info, nil))
+
+	conflicts := []ModificationConflict{
+		{PropertyKey: "ticket", Reason: `set to "OPS-2" after "OPS-1"`},
+		{PropertyKey: "approvers", Reason: "set after values have been added to it"},
+	}
+	assert.Equal(t, &Summary{
+		Level: SummaryLevelWarning,
+		Text:  `Conflicting modifications: ticket: set to "OPS-2" after "OPS-1"; approvers: set after values have been added to it`,
+	}, ConflictSummary(nil, conflicts))
+	assert.Equal(t, &Summary{
+		Level: SummaryLevelWarning,
+		Text:  `Approved. Conflicting modifications: ticket: set to "OPS-2" after "OPS-1"; approvers: set after values have been added to it`,
+	}, ConflictSummary(info, conflicts))
+
+	summary := ConflictSummary(nil, []ModificationConflict{{PropertyKey: "ticket", Reason: strings.Repeat("ä", 300)}})
+	assert.LessOrEqual(t, len(summary.Text), 512)
+	assert.True(t, strings.HasSuffix(summary.Text, "ä…"), "is truncated at a rune boundary")
+}
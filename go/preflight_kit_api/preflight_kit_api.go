@@ -161,7 +161,7 @@ type CancelResult struct {
 	// Error An enhanced version of RFC 7807 Problem Details for HTTP APIs compliant response body for error scenarios
 	Error *PreflightKitError `json:"error,omitempty"`
 
-	// Modifications A list of execution modifications (e.g. property updates) Any of
+	// Modifications A list of execution modifications (e.g. property updates) One of
 	//   - `preflight_kit_api.ExecutionModificationSetPropertyValue`
 	//   - `preflight_kit_api.ExecutionModificationAddValueToListProperty`
 	Modifications *ExecutionModifications `json:"modifications,omitempty"`
@@ -178,6 +178,13 @@ type DescribingEndpointReference struct {
 	Path string `json:"path"`
 }
 
+// ExecutionModification An execution modification (e.g. property update), discriminated by its `type`. One of
+//   - `preflight_kit_api.ExecutionModificationSetPropertyValue`
+//   - `preflight_kit_api.ExecutionModificationAddValueToListProperty`
+type ExecutionModification struct {
+	union json.RawMessage
+}
+
 // ExecutionModificationAddValueToListProperty Adds a value to a list property within the experiment execution's properties.
 type ExecutionModificationAddValueToListProperty struct {
 	// PropertyKey The key of the property.
@@ -208,7 +215,7 @@ type ExecutionModificationSetPropertyValue struct {
 // ExecutionModificationSetPropertyValueType The type of the modification. Needs to be set to `preflight_kit_api.SetPropertyValue`.
 type ExecutionModificationSetPropertyValueType string
 
-// ExecutionModifications A list of execution modifications (e.g. property updates) Any of
+// ExecutionModifications A list of execution modifications (e.g. property updates) One of
 //   - `preflight_kit_api.ExecutionModificationSetPropertyValue`
 //   - `preflight_kit_api.ExecutionModificationAddValueToListProperty`
 type ExecutionModifications = []ExecutionModification
@@ -451,6 +458,15 @@ type NegationTargetPredicateAO struct {
 	union json.RawMessage
 }
 
+// PreflightCallback Where a preflight supporting callbacks pushes its final status result to.
+type PreflightCallback struct {
+	// Secret Secret to sign the callback with. The `X-Steadybit-Signature` header carries `sha256=` followed by the hex encoded HMAC-SHA256 of the `X-Steadybit-Timestamp` header, a dot and the request body.
+	Secret string `json:"secret"`
+
+	// Url Absolute URL the `StatusCallbackRequestBody` is POSTed to.
+	Url string `json:"url"`
+}
+
 // PreflightDescription Provides details about a possible preflight, e.g., what configuration options it has, how to present it to end-users and how to trigger the preflight.
 type PreflightDescription struct {
 	// Cancel HTTP endpoint which the Steadybit platform/agent could communicate with.
@@ -474,6 +490,9 @@ type PreflightDescription struct {
 	// Status HTTP endpoint which the Steadybit platform/agent could communicate with.
 	Status MutatingEndpointReferenceWithCallInterval `json:"status"`
 
+	// SupportsCallback Whether the preflight pushes its final status result to the callback passed in the start request. The agent keeps polling the status endpoint as a fallback.
+	SupportsCallback *bool `json:"supportsCallback,omitempty"`
+
 	// TargetAttributeIncludes A list of attributes of targets that are populated to the preflight in the experiment execution. If the list is empty, no attributes are populated.
 	TargetAttributeIncludes []string `json:"targetAttributeIncludes"`
 
@@ -524,7 +543,7 @@ type StartResult struct {
 	// Error An enhanced version of RFC 7807 Problem Details for HTTP APIs compliant response body for error scenarios
 	Error *PreflightKitError `json:"error,omitempty"`
 
-	// Modifications A list of execution modifications (e.g. property updates) Any of
+	// Modifications A list of execution modifications (e.g. property updates) One of
 	//   - `preflight_kit_api.ExecutionModificationSetPropertyValue`
 	//   - `preflight_kit_api.ExecutionModificationAddValueToListProperty`
 	Modifications *ExecutionModifications `json:"modifications,omitempty"`
@@ -544,7 +563,7 @@ type StatusResult struct {
 	// Error An enhanced version of RFC 7807 Problem Details for HTTP APIs compliant response body for error scenarios
 	Error *PreflightKitError `json:"error,omitempty"`
 
-	// Modifications A list of execution modifications (e.g. property updates) Any of
+	// Modifications A list of execution modifications (e.g. property updates) One of
 	//   - `preflight_kit_api.ExecutionModificationSetPropertyValue`
 	//   - `preflight_kit_api.ExecutionModificationAddValueToListProperty`
 	Modifications *ExecutionModifications `json:"modifications,omitempty"`
@@ -716,11 +735,20 @@ type CancelPreflightRequestBody struct {
 
 // StartPreflightRequestBody defines model for StartPreflightRequestBody.
 type StartPreflightRequestBody struct {
+	// Callback Where a preflight supporting callbacks pushes its final status result to.
+	Callback *PreflightCallback `json:"callback,omitempty"`
+
 	// ExperimentExecution A single experiment execution that was triggered from a single experiment.
 	ExperimentExecution        ExperimentExecutionAO `json:"experimentExecution"`
 	PreflightActionExecutionId uuid.UUID             `json:"preflightActionExecutionId"`
 }
 
+// StatusCallbackRequestBody defines model for StatusCallbackRequestBody.
+type StatusCallbackRequestBody struct {
+	PreflightActionExecutionId uuid.UUID    `json:"preflightActionExecutionId"`
+	Result                     StatusResult `json:"result"`
+}
+
 // StatusPreflightRequestBody defines model for StatusPreflightRequestBody.
 type StatusPreflightRequestBody struct {
 	PreflightActionExecutionId uuid.UUID `json:"preflightActionExecutionId"`
@@ -1013,6 +1041,95 @@ func (t *AbstractExperimentExecutionStepAO) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// AsExecutionModificationSetPropertyValue returns the union data inside the ExecutionModification as a ExecutionModificationSetPropertyValue
+func (t ExecutionModification) AsExecutionModificationSetPropertyValue() (ExecutionModificationSetPropertyValue, error) {
+	var body ExecutionModificationSetPropertyValue
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromExecutionModificationSetPropertyValue overwrites any union data inside the ExecutionModification as the provided ExecutionModificationSetPropertyValue
+func (t *ExecutionModification) FromExecutionModificationSetPropertyValue(v ExecutionModificationSetPropertyValue) error {
+	v.Type = "set_property_value"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeExecutionModificationSetPropertyValue performs a merge with any union data inside the ExecutionModification, using the provided ExecutionModificationSetPropertyValue
+func (t *ExecutionModification) MergeExecutionModificationSetPropertyValue(v ExecutionModificationSetPropertyValue) error {
+	v.Type = "set_property_value"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsExecutionModificationAddValueToListProperty returns the union data inside the ExecutionModification as a ExecutionModificationAddValueToListProperty
+func (t ExecutionModification) AsExecutionModificationAddValueToListProperty() (ExecutionModificationAddValueToListProperty, error) {
+	var body ExecutionModificationAddValueToListProperty
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromExecutionModificationAddValueToListProperty overwrites any union data inside the ExecutionModification as the provided ExecutionModificationAddValueToListProperty
+func (t *ExecutionModification) FromExecutionModificationAddValueToListProperty(v ExecutionModificationAddValueToListProperty) error {
+	v.Type = "add_value_to_list_property"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeExecutionModificationAddValueToListProperty performs a merge with any union data inside the ExecutionModification, using the provided ExecutionModificationAddValueToListProperty
+func (t *ExecutionModification) MergeExecutionModificationAddValueToListProperty(v ExecutionModificationAddValueToListProperty) error {
+	v.Type = "add_value_to_list_property"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t ExecutionModification) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"type"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t ExecutionModification) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "add_value_to_list_property":
+		return t.AsExecutionModificationAddValueToListProperty()
+	case "set_property_value":
+		return t.AsExecutionModificationSetPropertyValue()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t ExecutionModification) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *ExecutionModification) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
 // AsExperimentExecutionStepActionAO returns the union data inside the ExperimentExecutionStepServiceValidationAO as a ExperimentExecutionStepActionAO
 func (t ExperimentExecutionStepServiceValidationAO) AsExperimentExecutionStepActionAO() (ExperimentExecutionStepActionAO, error) {
 	var body ExperimentExecutionStepActionAO
@@ -4492,119 +4609,124 @@ func (t *StartPreflightResponse) UnmarshalJSON(b []byte) error {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9i1Ijubnwqyj9p2pm8rtNt43BdtXWOV7wZjjLAAEzk+wMh5G7ZVuhW+pIasCZ5d1P",
-	"6dJ32dgE9pKQqsxid0v69N1vkr85AY0TShAR3Bl+cxj6R4q4+J6GGKkvDiAJUHTG0CzC84U4z58v5dOA",
-	"EoGIkH/CJIlwAAWmZOfvnBL5HQ8WKIbyr4TRBDFhJk2y6UaBfH98j4JU/nEUyqczymIonKHDBcNk7rQc",
-	"sUxQ+fO9O6eu+TJNcdi+vDw6LH/v4jihTMGVQLFwhs4ci0U6bQc03plTOo/QjhzoPDy0HC6gQPLVPzI0",
-	"c4bO/9spcLKjt8B3cgxcqLflOIkrzFDoDD+v21G2wFW+Dzr9OwqE8yAnCREPGE7ku87QmSwQeD+ZnAFD",
-	"B5DAZURhCBLIOQqBoEAsEOCCJjuBogzIVwaIhAnFRPC2RBkWkVzrQtAE5MADQz/noeVcCMjEMxMW3SeI",
-	"4RgRkWPgMcSOm0NGpxK+3xKTbENtGw6elfaQicepLl9aRXaR8leB/k0JdEFOrshjp2pBT03EgqwKeJ5Q",
-	"wldobf1sKwpDsjydOcPP69GoVzpHPI0ke22I8x+xGDNGmfNwZUFaBi6YUZbrOwvLr1NzZsMPLedQzT1F",
-	"vwQ28jUOSxt6KayEZmPrMZNtfwV28m+PMf9FMCPXeTGUFJiIMLeio8CCBMSOCi1dL4iMfIGXFRq7oViv",
-	"UAp01P2Dl8QGE78+Mpo2M8OFNCl6fbmp0ZQLBgNhcVwuBEpGp/KlKjgjwAVKgFhAATAHSL2PQgA5SOSi",
-	"dAYgAYXjIJV+iOUMMSZQUCanjGGSSGspQTiYHJ2ebOFRKcAC41u1nIvx+cejg7H7cXR8dDh6wlwXiN3i",
-	"AH2EEQ5hPu2n0dFky4k+QSwyd085FMsTGGvXACUTaV0fpEcF40TS6PM3B4fO0Nn1pt5sf7DvDvxO6O52",
-	"entuv9/fd/1er9vb8wd7HThwWg6eE8rQDxBHKUPOcAYjjlpOAhmMkUBMUTNMGTRU8j1u/M4QBYhzyqT7",
-	"QtIoerhqOZSgDXj5Mdw/xt+PoelJo23Ukluq+nBBygWNj+EURU0OPlAPQSSfAsg5nhMUgjCV/luJcaVN",
-	"wnMi3Rp0nzDEuZI+rPQFpkRyulhgruTBKVHWOaBEQEwQA2/ul/98AwJIAKECTBFgCAYLFDbcRskZJERh",
-	"E9oJjhEXME7A3QIRvWIJSCWMemgZhI7X6bqe73r+xBsMPW/oeT85rcJ7DaFArsAxskGCLWBcEvyPFAEc",
-	"ys3PMGKV7Rs1IF8tQ7Ehb+dQKa/XBlCV+euwfVogsUBaOZZRs6BpFIIZxNEOkvoV4DhGIYYCRUuACQgg",
-	"R6U9yBe5flO5qvk+jLAZsKaURggSJV4V8YNhiCVEMDorMaNgKaqrdMnILk9QgGc4AMUsGqeoQd+Akhme",
-	"Z9Jdgswm8zU/vqEENqQsqhFWa/yEoQCFiBdoawGpVQCeAawMghw5w4wb0KU5ABEk6EUYgyFoDHXdZsrv",
-	"qzRuCE2+M0l4FALKgCJ+TZQOJBeRN0LKbqhiGiR3FWRC3m63baApK/1Ugb6DHGQTPJtY56FkXR0yphc2",
-	"OyskApM6O75F7XkbnF+enByd/LkFfhgdHY8PW2B8fn56Lv84OP1wdjyejA/fVeA2A+xQGePYRNQyQSu0",
-	"jIFD+w4tII11dUHjVTTWq0XG+eJXFrkZCcHwNBXI6gYRALPnHLy9QUv3FkYpchOI2bvcPYKc00BqHBUe",
-	"QyAgmyNRFeEbtHSGTsFPKjnQctR0ztDp+X6vM+x09/dKroWxc2roN0ucfoOWmRjnYLZADJfSCJWAitNI",
-	"4CRCQDINz/MyMEYWUEswIjLHSqZjTI4RmYuFM/Qt1DWbsIGoHjWArKwX0uAGscdWqdFU4iRb2EbW7yPI",
-	"xTkMccpthFWPAVPPCzLK0EDTMEQzTJBGE1LurkaUpDW4Q5F0KaQHTBNtCwCDJKQx4OmU1ymfIBYgIuAc",
-	"OcOepzW1jEDUQ0ln7S87o5NDp/SUK9etzjYLysUOgUr2S2PHf7kcHV9kGJFDDVrdEPEbQZOdGBN8k06R",
-	"c/VwJe2H2o4WSSegcZsLBMPlFIs2uheIcEzJdbFs/leTO2N4j+M0buL4yOhlCGb4HoWApPFU2x2p9xSi",
-	"5AdjISXeDYqNSZ8igGYzFNTUY6ekCTER3U4h/9JrmxsYS0hfDVjxVgkgRfQtgdr1NoOqTPp1fvFErXqW",
-	"va4DjjLNGrKmngG5ZBG1GUDBdKm/g0HDeSsIa5O4hlhVkmfNZLqKb4dbB8QtJ6ah9JKUk8MfT8AbA/Gh",
-	"MkqamTSOIVs+NsGFeU3F4SbdhMl8bELsczRDDJHAgmaVGc1CcXC3wMFC8ctFJj0giaCQnLAD59KUBopr",
-	"AhrHKVGkBHdYLNpOQ4qQWNDwMcDPEQzfC5F80G8r51Sqy4bdmnIapQIB+Tjj6QrsCgIoBGLy/f/daf/p",
-	"j4/aUbVWKwP1qkhEqJkz7IECfQ8tx0qrzTMt1uEXUjJ07P1RGYDHo0zLLKMwVKMn9BjzfEIVZjacgMIl",
-	"KTOq8U+yPABIE+mZvQMjIs3yFwKAC77mGZzrGyyuYYLbG+3p63bD7Zv5WkoW2QmxikIrkNNktDDkABor",
-	"r3wflcbMUSKZvelf5uh8w0EhBk2hyKb5cQMHKHtXzhLD+8yR2Nu1+CvCrkIXSOtPM2OZ1G1wglCoXKep",
-	"dgkEtRFnBR1UoEmkkfwsA8hrha9rQa8ltq4z0EuOjMW32jzwLPwuSZEwLEUuRpNX61fSQLEZDNC3h4bM",
-	"q1daFUqU3a51zLWChyxWZTMpbxDsQpKh7GPC/1zGa+iPMstxVDDZdd1nfj5W40j8SqzW4JVNmYzbUt9K",
-	"hdHZCq3P7Wqf/8b0PhYofponpRh1HbZ5gV7IGFxq7NqaImx1BUzmkV0otZN6BzkQDM/niKEQzBiNAWyO",
-	"aldjrIAhqPIwluSJ3/bU/35yWtl73yuxJjpx/wGSFEWS83AgUoYuWeQMnYUQCR/u7LTb7R3zoJ2o0n/K",
-	"ETND4dxfTPdh4oadwcDd3YdTdzDoz9ygA3vd6W632+94Ejtm2Y8YOkPn8sjJ88A1cH1v6HklcIv9fkSM",
-	"axT2nJazWCZULBDHXCdGSYGi3KHXqMvDOjdEMd2ZQb7AlLhTxAVHUSRfgzjSCT2+oAlQWRgcReCOshtM",
-	"5jJYk2DIQEIiHYfOsNf39r2WiU0v3p+eub7TytB5IWcx0RJP2S2+RQV0CQ0BTVVYVuT1nIMFCm4UIBLL",
-	"Titr6lpB0DKGTLrL0TmqZnSqe44yiq+ThUuOmAkMdLiV89Sjub0KO0v+zYauz+r5m2f1Knz7lF0o1rMZ",
-	"HvVcCZ+WOS2EAUxVs0ctbVkSVW2PiuylMTWjM8ndB8fyX8XoFwfvx4eXx2On5Xw4OJOavECJeuHJJZKq",
-	"Enne4ohF8OrwjBs1pFv9qgkLA0gkilLTNGMy70sQLCCZIw6mSNwhVC6iApYq3ZrvobdRUqGsDGwULp7n",
-	"mYFbXVjLUgNlTFZweLHkAsUqNzaNtI3PJTqCApFgmaWPCRJSY0i76fc8L+Zb1pysXIZDA3JWtshKEllV",
-	"xTaqvAO/1+luhERrjrUJmvT7mhBVDBM4oPEUE5hVD78KBGM58CuAJASYSImT6tAkxKoZ7cMPbseGOa1c",
-	"6wCewBhZykmCArlEtCy4bh2RlcLWdOUgJfAW4ghOcYSFcnIXVBoPGCmXOUwDYSVtVe1u50EWYy2bsdL1",
-	"mzP+6+jD2fH4+uDyYnL64frs/PRsfD75m7ImaXADTihjkm+jiN4Zxi9mshfQMiBWirx5UPP8JdgzylQu",
-	"OMZc4ABENFC2822eHzZScql8RXd0dlSpZPgb8ejGdbAV0rS+AqYr/vqRvQqX2+TtDWIx+DkLXeyJ0GxW",
-	"dOu8RNHNUmc7OB+PJuPD1rMX3FbYA/XIJIQZKnp7MFFF6ihCkeQRLklGAtSsD1Z878/fntS18tD65uh0",
-	"9FG4UQWCC4Y4vw6S1GmZkT9iIseOJpPRwY8bds8ESXpMYegMfc9rlaHqelKtSROm3vSaJfVN69m6qvRv",
-	"WfnRY/MITwNaVEitcK8rdDbfzkuO2fumOmh7t4yR6uvNva8av3KoSgSbkEY/Ge7s+LP9vUHo+dNB0AvC",
-	"zmA67XqdAO56fm+/s7876/b3B33f2+14fd/fn3a7vtfv9KZwb3+v3+8iWApYcpEupHcrYly1HEEFjHTh",
-	"54CmRDhDX369UeT/eE/gQzPIF3Bu0ylwbrPbUCfnpMo0jzI7tNIgfHYY4jjCqnjQcmQ86pQ39FLrOp+/",
-	"lFb+4rS+qLW/OFfOyiSewUrLSZWTeKRhlB6OyqQxLD3mNb7Q1n1xH82cmjQhShgKdHxqc6uyt7lyOsUC",
-	"YQYow3NMjOpfwFsEpjIAURGKbjvNWz1V5vEwX2Oon80wikJwh6MIRPgGRVkwo6NBIRADTI7hyglVQcEd",
-	"mMLgBpEQZDgBXLBU5VOM/dPV4VveBuGSwBgHgKMIBSJryJOK5l0bHFLVXsfkstLbERwEmX1dwATVMkIL",
-	"IZKsJKXUrdq8VJgnH4/OT08+jE8mJeGXrw93dkJ025akb2eubltlLAmMbPXQB2uacX0/pbXHRD10t2+5",
-	"Le33uY1pKUHVc33P9foTvzv0/aHvt3cHXmev/5PJBjmePxgE3VnH3e0H++6+txe6U9/ruINg6g3CcNef",
-	"9sJX8/wLmOfcJW5SzfPbu/393q7/0wobVHRoldqqXs39b9zcN+LuoDjEtmEDqB7S1DuYrGh83lK9NOxn",
-	"Wd/UoZTf1gDTRgIKAYObFggWSP4nojAUiIt35dRjproO3o/Vf49PR4fXk/GF1POnk/fjc6flfD+6ODqo",
-	"ZiHzcc2s62uD+WuD+WuD+b9/g3nuEqzzyastpa996a996dv2pds8qvq6x6b5IOs7LeXpdBHWlN6qzZ2Y",
-	"G3PZBhP5QbUwxDAP0HJezLqYGZJMZVL9Ecqi5lybqPC53ANRy/m9eoG/khe4UXZnUmWzFdmchj/ZCE1j",
-	"+b2dGxX7STcGqHkqHdq1ktverqWcsc0ZjS1OCUoDGkUbdJZukgCr9zdzveB25k+Pab+I4brNt75Gk2jF",
-	"wEGCmJywmlLQ6k3DCIrplMZrb95G9NgZ0hrzNf2LZtftKqienCGpZDS8PdfvT7z+sNsZ+n6739/zdrt5",
-	"RuNljuvurTyu2yqzlhME3t7M73Zcb2+w6+7OZtCFCHVcvxv2Z3AGu7A/dRrRfnlHnt/u97zd/cEG0b7l",
-	"aHWNsT5fPYOwmgPBlo6wO4jF75yy/jrKPg+Z1FH1h9cj0K8R6muE+noE+jXUfA01n+0I9Ppi44pjvVl5",
-	"kSFOo9tyBbHUnGdma4EbtNShqgyu2mAMgwVARLAlCCBjWRddPpeKzt7yd1nxEghs1N4dJkSaL3PmQxX1",
-	"VCOj6nZ+WyrutYDxbFpg/Nez8fmR+dL0o8pvxweXEnvv2iDrS9fHu/PtveFmIRlfA43bFoD6QPW6dwlQ",
-	"nq4636pG8TYYx4lYgpQIHNUK00oCeEu6O1kJVJeLVKiVY4WSQAXqLDVDMvSoj0v5jwzlsxuE7hY0QvLl",
-	"jSujGUKaddE1NdGqQ5JN9i1Py1frrYYmTsspiFLtEi6guNr8qPkYK8OY95lrSryVaAhoHFOtH1sAkyBK",
-	"Q8lAht4oQlob6ZIzUydb+TupI8tENOR4Wya8rUrtlK/dqQP/rYim1jcVPFxZS8sfUgHF7+Kcagbp7+us",
-	"6kr8fsJicQCj6Ejy/S2MNs8yrCZZM7sQ1FaooUmSEQowY7ovb5m5feayMIEKek8RkJOh8L/ABN4gDjLn",
-	"iWeWThtS1Z7se17Mv0qG/+p7/GsN2V++hP//LeE/x/xn/nP88+Ln8N0fNzkq3ojnn5EjtyBliQFLKuns",
-	"VNUEzy7lv4djacetyuYEzRXamqfxN2YA20H+OuEJFU+4D8CK84bOsN7v2GCuM0ZvsfR2QyTUcSA4pakA",
-	"ECSUcyyNSn4erQWkR9LSzFjx181lGFz6xwvIW2BB72RQmTUEYd2YTkI35TIGkMbLvFKcPSmt1FQ++jDP",
-	"psrHJnPVfdfRUMKRsqIFrIKCBYoSCWAMUhIixoWEX2Gheo8j5iCkmMytzjIObOuOCJAP8vxH48zIkqas",
-	"vIQW4hRXT4dCEEIB3ZThzGlX57cIwDGco9r5VF+foaoIuxw+tGpXe6w8AgIFCymdETg6bICvO+OqpxBk",
-	"4GTc3YLQ4G801W1lYpnI2aIluIP6IEPKEeBU6nG5mQjfIPCVsnnbODTteOnOIAmWbj7dVyvmI3uOZAQW",
-	"aQyJK2Me5T3pbEnjes7Voc+/xI768twnT9EwTHmJKb/W6Eh5PGjtQdfSHUe2XH9CkzTKbjeqMfvqI9Zt",
-	"cKRtuVpFxn7S/20BQsvrVeavJJwfcZFazu2qYxoqQKkf1ch57RzFSF1EIwVMH8ZBpWPk6Baxpap+LWlq",
-	"zvaay2tnmKi8B28DdWrF2CvNuTp1AyBZlt7MD3tJ01VculTgz4DZBp8QCClgSNo7REKQcjhXUsJRfItY",
-	"HkU86vio/IFm9gJFVb23mkcyls4Z88p29ephda6VJie/4MWm8BBZSG0elil1/sMB2O97++CM0WmEYnBo",
-	"zJEUR2XtR2dHXPoESYSlfsiukAZTGi61ylb5Lx4gAhmmvGFCtIHbQA+g+ySC5hRXURWlWoXRQLeeBqh0",
-	"FkhCbFf6RBoLm5s+ApfnR4Bl4mw9WpYvvt2ihWYJ0Qyq63qcIutTBeNPWWbI1YydU3oBlUugC81SC2eH",
-	"dHlLY1srYQDV91JBC8Qz704OVg3GWL4jlUNx3rAkj9UVlSwp/1Wf220BnCdG1IW/mAOGRMpIMVntBvI2",
-	"+FOW39IbYkjlmWDJWmk2uVvgKI/DJfBVyD4ZWGLIbnQxgmV9cYX0v8QGSk1tmjDyC0M7m59qRNRy2H5B",
-	"mWjVWdvcklTjImWX287Gt1ZswruPTF2/AEJto3zXQ3Y9VPUubmulUx31K+hgzFeZUpW4wpSYbFd/ZDPI",
-	"TxuVP9fdILXJeYFVF/mvUL8KB+v07kWW/9w8UT8iS3Bjei9N32Wud3Rwqe9mMMxd/S0Agu5X/KqDOVOo",
-	"AbKA/JcUseUxJPMUztGLxVf/kKtYXAprBFWmhB5oC6tqF3GVYss/jydWGS3fGf4bvDjtKT9A8YT71upZ",
-	"afuvUmS/+bEKXcoBQNaig2ocVnKueFXGQZjo62kSGkU2dSsVe0T1TRf5zFJNq3KYClMJNRZDNUuUpK9U",
-	"I/sPpWFBiytJt2Iyqy3KbU9FnYSYJxFcFsbVenzZ5CaaKjtCt7bQTp11le48Fkug3skLfhqIsp3FZEad",
-	"lnMHmQyamxLccu5d+a57C9XlL+okh9ntsZz7SE9Q/upTNplUNOherLj5SWpPHcA38FACtBS69/zOo6ZU",
-	"LtcymClZkYw+FpHTmnQkZecofDFdDPX8lR/ZsRc9H9XO2VRXqzeTBTg/oqVqcXuxbZnrIza9J3eT9xTA",
-	"p/lJolJB5S+Xo2On5ZycTq6zv/+szpCfX0/ej05qH69Pz/PXjscXF9k7+d/FC1eP38G7niTlW3mt29iM",
-	"Vr8ymWgJ6c+BD7r17rn0HX9lLCQGDBsLnp2PL3ThTjJh9umZ2Kex8GZoU/eg/V44pzgPuHlRcFtuy9dY",
-	"jb9H7moT5lLhvC1vow702rnU10bxX6FRvGUzu+8pF/Z7hCa512yan2iAeH4BmGYD18SFAY1j6RPL/88o",
-	"u4MslA6LKJLDcmGQAwiy9Fe1BTphNNR30hVX1CkQ6p9dz3qIkAk8g4FY1/dsXil1fJWS2qXr8U1yNt/p",
-	"ikMPzt9V85rLkGTa9j9xIgmmbshyYyQYDng74Ldrbix4Icicz1+asOlbDBrQbXKngURvSWotrjyqlw1q",
-	"VwqEmAf0Vl/gmLUF6R20wUhdovXIjxTou4RNM4cZWTuB8kKaZOMLNEq/G7HZpRDbdZuuJbjnD+AU9jpu",
-	"r9cP3f1wd9ed7g+67qDT7w5m3aDr9zqbtBXatcFRo0NyM0Ng+VWH59GK27ZDUqLv9s80lw7+7hb1a9Ca",
-	"DZGmaJX14YVYNUQ2XgNvv+qs2B++A290b+AbpRCLb02n4Jt3prNN92ly8JWkUfS1qgt/0PMLatq61G9D",
-	"Fs3Vbzc5cU2Td6sxZeooTYSdZveTFanCDNasFWA9Mh9FaLPF1FzjUe4xNfyV72cI0D3Of1rUB2+lTvju",
-	"i2kLHbidwcTvDHf9Ybfz0xdHB/ff6QxNzOfffSkEHYQUcbUiusdcfHGstRKasmCFWdTPwFvcRm3VVZPZ",
-	"uZwM72pXZpqGYpHljKi+Y7WKibPTiwkwzXW+3+547f5eu9PrDQfeoLuzha2/Vj+tnDCUQLZNN+xFtQu2",
-	"SPaixE7yGiWbDkppyc1SSjUftHJtqXikU/ZJOmnLH7tYCZ/NJJq8ljR3cK3yzvNVztHJD6dOlhpy3qMo",
-	"ouCOsij8Q9OBy3NcTdNtMksbb+gExi8XJ2UWZdvQT41bHajU4K1iX1URTNXdVPGk8yFllacJYrVf8Jki",
-	"df7FwiAvda3Lpr/FuLrf7LFfuFhZSHls4Mqs34YD12XYtp/iXx7dSJxsP0sjj7DZFHWp2myUVGiVUatF",
-	"oP7qs4qtXPN5kxF6Rtt2qldUW41uyouLqHUZfWVZoAUoiZZFk+HMculbNknt1GCsOkGcGN7/d2FsAxoX",
-	"d5l/gPfgQ8oFYjEk5Gl3v9cqfbFpP3nCDb9y8Yo9+x9IEDikVrNfvszeslqxkUZIYp6UV22BrOW/Fqrk",
-	"3bsVuPwuvO3sd/fdadfvu7vebt+F+4PQ3d3vD8K93d406PrVkkan13uspJFDbP8V+4ASnpq6TOM3nmVQ",
-	"R2ZUGVIcIPOr0MVWJKmPJiW667OWxQ8tZz3K5fod0Hed521ojt/22p5JAxKYYGfodNt+29Md1QuenQjV",
-	"5yhWgfrwfwEAAP//v54Jy+KEAAA=",
+	"H4sIAAAAAAAC/+x9DVMjOZLoX9HV24ju3mcbf2A+HDHxngeYbd7QDQd0z95M80CuSttayqVaSQXt6+G/",
+	"X6Sk+pZNmYWdmV0u4mYbV0lKZabyW1nfPJ8vYh5BpKQ3+uYJ+HsCUn3PAwb6hwMa+RCeCZiGbDZX59nz",
+	"JT71eaQgUvhPGsch86liPNr6m+QR/ib9OSwo/isWPAah7KRxOt3Yx/ePvoKf4D+OA3w65WJBlTfypBIs",
+	"mnktTy1jKP79tT3jbftjkrCg8+nT8WHx9zZbxFxouGKq5t7ImzE1TyYdny+2ZpzPQtjCgd7DQ8uTiirA",
+	"V/8kYOqNvP+1leNky2xBbmUYuNBv4zjEFRMQeKNf1u0oXeAq2wef/A185T3gJAFIX7AY3/VG3uUcyPvL",
+	"yzNi6UBiugw5DUhMpYSAKE7UHIhUPN7yNWVItjKBKIg5i5TsIMqYCnGtC8VjkgFPLP28h5Z3oahQz0xY",
+	"n4bhhPq3jbF5kA54aHnwNQbBFhCpDH2PzXNUHzI+xbl+Txy2Cau4cPCsjEOFepxl8KVVPKMSmRLtX1EY",
+	"CJBJqB7jO4OHc/PuRgS28/9jNE3kPKdpeuYInxZonI7hkQ/655zocyoJ7ikEBUGF8CqRJCVvnfCvauB3",
+	"pQZykkpDOedxzg+ypW9GVsPuMY/kCl1vnm1EYRotT6fe6Jf1aDQrpeen1RDnPzJ1JAQX3sOVA2kpuGTK",
+	"RaYlHbJunXK0G35oeYd67gn8M7CRrXFY2NBLYSWwG1uPmXT7K7CT/XrC5D8FM7jOi6Ekx0TIpBMdORYQ",
+	"EDcqMp3wUsgoK50XOzRuC2G9QMnRUbUqXxIbQv32yKgbSykuUKWY9XFT44lUgvrKYbFeKIjHp/hSGZwx",
+	"kQpiouZUESYJ6PchIFSSGBflU0IjkluMKPQDhjMsWEQVFzjlgsYxaksE4eDy+PTjBqa0Bsy3RnXLuzg6",
+	"/3x8cNT+PD45Phw/Ya4LEHfMh880ZAHNpv1pfHy54UQ/UaZSO18bFMuPdGFMA4gvUbtqd4KijaMZiQXe",
+	"yNvuTrrT3f3d9n6vH7S3+8Od9t7e3m67NxwOhju9/Z0+3fdaHptFXMAPlIWJAG80paGElhdTQRegQGhq",
+	"Bomglkq9rjSAQAA+SMkFmi9REoYPVy2PR9CAlx/D/UPrSeMzND1ptItaD1cZylOPL5GKL07oBMI6Bx/o",
+	"hyTEp4RKyWYRBCRI0H4rMC7qJDaLiOL4owAp9eljWl4wHhnLlkl9HrwCZb0DHinKIhDkzdflf78hPo1I",
+	"xBWZABFA/TkENbMROSMKIKhDe8kWIBVdxOR+DpFZsQCkPoxmaBGEfrc/aHd77W7vsrs/6nZH3e7PXiu3",
+	"XgOqoK3YAlyQMAcYnyL29wQIC3DzUwaitH0rBvDVIhQNeTuDSlu9LoDKzF+F7ac5qDkY4VhEzZwnYUCm",
+	"lIVbgPKVsMUCAkYVhEvCIuJTCYU94IvSvCk7xX3Yw2bBmnAeAo28h+rxo0HAECIanhWYUYkEqiIdGbkt",
+	"Y/DZlPkknyV1lqr09Xk0ZbP0dBcgc535ih1fEwINKQsVwhqJHwvwIQCZo61FUKoQNiVMKwQcOWVCWtBR",
+	"HZCQRvAijCGAWkVd1Zn4e5nGtUOT7QwJDwHhgmjiV47SAXJR9Ebh2Q20TwO4Kz895J1OxwWa1tJPPdD3",
+	"VJJ0gmc71pkrWRWHQpiF7c7yE8GiKju+hc6sQ84/ffx4/PEvLfLD+Pjk6LBFjs7PT8/xHwenH85Oji6P",
+	"Dt+V4LYD3FBZ5VhH1DKGFVLGwmFshxZBZV1e0Dypr1fxjLPFrxznZqyUYJNEgdMMighNn0vy9haW7Tsa",
+	"JtCOKRPvMvOISsl9RpVxjylRVMxAlY/wLSy9kZfzkw4OtDw9nTfyhr3esD/qD3Z3CqaF1XN66DeHn34L",
+	"y/QYZ2C2yIIuUQkVgFokoWJxCASZRmYBOboAB6gFGCGaMX2mFyw6gWim5t6o56Cu3YQLRP2oBmRpvYD7",
+	"tyAeW6VCU8RJurCLrN+HVKpzGrBEugirHxOhn+dkRNfA0DCAKYtM8EqCNncNopDW5B5CNCnQAuax0QVE",
+	"0CjgCyKTiaxSPgbhQ6ToDLzRsGskNXog+iHS2djL3vjjoVd4KrXpVmWbOZdqK6L67BfGHv3np/HJRYoR",
+	"HGrR2g5A3mLIfsEidptMwLt6uEL9obdjjiTmYTpSAQ2WE6Y68FVBJBmPrvNls3/VuXNBv7JFsqjj+NjK",
+	"ZUqm7CsEJEoWE6N3qLSIwj+shkS8WxRblT4BAtMp+BXx2C9IQhapQT8//yxSMLMwFpC+GrD8rQJAmugb",
+	"ArXdbQZVkfTr7OJLvepZ+rpxOIo0q501/YzgkrnXZgElk6X5jfo14y0nrOvE1Y5VKXhWC6pqxeqNNnaI",
+	"W96CB2glaSNHPp55sQriQ2kUqplksaBi+WgI3b6m/XAbbmLR7Mi62OcwBQGR70Czjoymrji5nzN/rvnl",
+	"Ij09JA6pQk7YojOIFPE11/h8sUgiTUpyz9S849VOEag5Dx4D/Bxo8F6p+IN5Wxunal4HczyRPEwUEHyc",
+	"8nQJdg0BVQoEvv//tzp//tOjelSv1UpBvcoDEXrmFHskR99Dy3PSyqloc7VfZAZrA6S+NklitH7etUgh",
+	"2mA4nClJbnADNx1yGqHO+RIR0iY3WQzl+papaxqzjhOoCzxvZpXPKERvNhs+DgI97JJjmC6d6eaRuAgN",
+	"gmstsq8Vv8YI4HW6042Y37241/Ik5DNep7ZG83mrOHGEPZQNeTSNNjRb5QmzrMDBQ4FPneNWcumKCeu8",
+	"GwSSUGvpaPsPCZmzLB74uo2dsfsbSXJRUBcM6TQ/NjAC03dxlgX9mhpTO9sOm0251cgcjA6xMxaPYod8",
+	"BAi0+TgxZpHiruOx4iQgUBChofDLOra/WmdfNne+c9sTKRIEHa+qzco5PBYpEFPqw7eHmtzTr7RKlCia",
+	"nuuYa+XBrGnWZiejRrALJEPRzqb/voxXk+BFlnNIwpdgNQnqN2K1Gq80ZTLpCv9rEcanK7SydKtl+e73",
+	"pXmZgsXTrEnv4RFsyxy9VAi6NNh1VQS5cissmoXuQ2kMdYwMKcFmMxAQkKngC0LrozplP9MXQHUsyhFA",
+	"6nW6+v8wjmTf+14f68ho8Q80SiBEzmO+SgR8EqE38uZKxXK0tdXpdLbsg06syx8SCcIOpbPefLJL43bQ",
+	"399vb+/SSXt/f2/a9vt0OJhsDwZ7/a73kC37mVEMRx57WSy8Am4P410FcPP9fgYhDQqHXsubL2Ou5iCZ",
+	"NMHhKEdR5tQY1GWubTuABd+aUjlnPGpPQCoJYQjCBIWN4zfnMdGRKBaG5J6LW8wXUBO8823RCgu80XCv",
+	"u9ttWf/84v3pWbvntVJ0XuAs1mOUibhjd5BDF/OA8ES7pnls0zuYg3+rAUEse620HHIFQYsYsiE/z8Tp",
+	"6h66qdZLKb7uLHySIKxzND4tEK1BfLPEzsi/6dD1kc1e88hmiW+fsgvNei7Fo5/rw2fOnDmEPk10wUsl",
+	"dFs4qkYf5RFcq2rGZ8jdByf4X83oFwfvjw4/nRx5Le/DwZl3VUTJp2PXXpumicpC5HkTRI6DV4XnqJZH",
+	"uzOvWtcYc2ITIIktHLLZhyXx5zSagSQTUPcAxUQyEUkki3sYNgqsFIWBi8L58yw6cmeSi2l4pIjJEg4v",
+	"llLBAkfQSWh0fHaiQ6og8pdpCD0ChRID9WZv2O0u5IZ5NyeXscCCnKZu0rRMmllyjSruoDfsDxoh0Rln",
+	"roOGdl8dopJiIgd8MUGH12ZQbxTQBQ68ITQKCIvwxKE4tEHBclT/8EO778KcEa5VANEPdaTUFCe4RLhM",
+	"wVxPZC2wDV0lSSJ6R1lIJyxkShu5c47Kg4baZA4SXzlJWxa7m1mQ+VjHZpx0/eYd/XWMiZjrg08Xl6cf",
+	"rs/OT8+Ozi//S2uTxL8lH7kQyLdhyO8t4+czuZOIKRArj7x9ULH8EWysXOGxYgsmFfNJyH2tO99mMXJ7",
+	"Sj5pW7E9PjsuZXN6jXi0cS5wxWlanwU0VQ/mkTsTmenkzRViPvg5k33iidA0Szz2XyLx6Mg1HpwfjS8x",
+	"tfjcSccV+kA/skFxAXl9E4t0oj4MIUQekUiyyId6jrTjVap8nlC5g2EuE5I/DhplYaQSIOW1Hydey478",
+	"kUU4dnx5OT74sWEFkR8nJ5wG3qjX7baKUA26KNZQhek3u/WygqY5fZNZ+5fMfpmxmYdnAM2zxE641yV7",
+	"629nadf0fbMZ57tFjJRfr+991fiVQ3Xw1Lo05sloa6s33d3ZD7q9yb4/9IP+/mQy6PZ9ut3tDXf7u9vT",
+	"wd7u/l6vu93v7vV6u5PBoNfd6w8ndGd3Z29vALTgsGRHOj+9GxEDQyJc0dAkvw54Eilv1Hu4auj5P14X",
+	"+VB38hWduWQKnbn0NjXBORSZ9pGdf7VC+MUTIFnIdAKl5aE/6hU39FLrer98Kaz8xWt90Wt/8a68lUE8",
+	"i5WWl2gj8djAiBaOjqQJhhbzGlto49rAz3ZOQ5oAYgG+8U9dZlX6ttRGp5oDE4QLNmORFf1zegdkAhAZ",
+	"D8WU3mblrjryeJitMTLPpgzCgNxjdCBktxCmzozxBpUCQQSOkdoI1U7BPcErLRAFJMUJkUokOp5i9Z/J",
+	"kN/JDgmWEV0wn0gIwVdpUSIKmncdcsh1iaHAZdHaUZL4qX6d0xgqESGM36RpOfzbbB4F5sfPx+enHz8c",
+	"fbwsHH58fbS1FcBdB0nfSU3djo5YRjR05YQfnGHG9TWlzjob/bC9edlxYb/PrUwLAaphu9dtd/cue4NR",
+	"rzfq9Trb+93+zt7PNhrkdXv7+/5g2m9v7/m77d3uTtCe9Lr99r4/6e4HwXZvMgxe1fM/QT1nJnGdat1e",
+	"Z3tvd7jd+3mFDsqr1AqlZa/q/neu7mt+t59f5GtYBGuG1OUOi1YUf28oXmr6syhvqlDirxXAjJKgSlH/",
+	"tkV8DBW3CN7KUyDVu2LoMRVdB++P9P+enI4Pry+PLlDOn16+Pzr3Wt7344vjg3IUMhtXg/S1yP61yP61",
+	"yP7foMg+MwnW2eTlstrX2vzX2vxNa/NdFlV13RNbfJDW3hbidCYJa1Nv5QJXJq267JBL/EOXMCxo5qBl",
+	"vGhnpQKQqWyoP4TUa86kiXafizUQlZjfqxX4G1mBjaI7l2U2WxHNqdmTNdd0gb+7uVGzH2E626RoWKpS",
+	"r6TcdrYd6YxN7qlscFMSFWgYNqjGbBIAq9Z4S7PgZurPjOm8iOK6y7a+RpIYwSBJDAInLIcUjHgzMJJ8",
+	"Oi3xOs3LiB67R1thvrp9cdWqVwq5oXpyhKQU0ejutHt7l9290aCPEY29vZ3u9iCLaLzMleWdlVeWW0XW",
+	"8ny/uzPtDfrt7s7+dnt7OqVtCtBv9wbB3pRO6YDuTbyat1/cUbfX2Rt2t3f3G3j7juvlFcb65eoZDqu9",
+	"FF2XMeSeMvUHp2xvHWWfh0z6uv7D6zXwVw/11UN9vQb+6mq+uprPdg18fbJxxdXmNL0oQPLwrphBLBTn",
+	"2dlaWP5mXFV0rjrkiPpzApESS+JTIdIqumwu7Z29le/S5CVRzIq9exZFqL7snQ+d1NOFjLra+W0hudci",
+	"1rJpkaO/nh2dH9sfbT0q/np08Amx965D0rp0c8U9294baRdC/5oY3LYINZfK170bEW3pIlXNKNkhR4sY",
+	"bwtEioWVxLQ+AbKF5k6aAtVTaT89x4ruKUh1magZkqJH/7nE/6Arn3ZRup/zEPDlxpnRFCH1vOianGjZ",
+	"IEkn+5aF5cv5VksTr+XlRClXCedQXDW/bn/EtGLM6swNJd4iGvD+KTfysUVY5IdJgAxk6Q0hGGlkUs6m",
+	"TaR8hzKySERLjrdFwruy1F7xMmAV+G+5N7W+qAC7ZDnO6YdEUfWHuKubQvrHuq+7Er8/MTXH7pzHyPd3",
+	"NGweZVhNsnp0wa+sUEETkpEqMhWmLm+Zmn22YZqCnN4T050Ugv9DLuktSJIaTzLVdEaR6vLkHhZr3yDD",
+	"3/S68qaC7C9fgv/9NpK/LuSv8tfFr/Nfg3d/anJdvubPPyNHbkDKAgMWRNLZqc4Jnn3C/x4eoR53CpuP",
+	"MNNoq3ckaMwArmYGVcJHXD2hJ4IT5zWZUe/47PIqhG4Ikb5KZBJjABfFZNrlVprut1LX3UwZljTb5qdG",
+	"ZBLF68JCgi9AuS5u4u/6OoF2PIvddDWRCdoYN39tZ8zRvmCziKpEwA2ZAw1AZIbDjZzT/nDnuxsy5Vjs",
+	"nV9qmMNXApHPAwjI+w/jg/bF+3F/uJPKmNL8mbGazo9qPuCZis1awk54sHTawYkI1wi3T+cnZtGVrZxv",
+	"CJMEOVOH+Dslr0CwRwUeLt9KMX7l6hqZrumtY5NiK9Tabs4Ev2PoFAWg9K0xOuGJQtbhUjK0PTIeahE0",
+	"XFtGZpXcOts3BjmJzKlskTm/R1ZI68aYZgyIgnYiQZj6NftKfkWpsFLHc9/5aqqjXKLZC9ahoYAjbWzl",
+	"sCpO5hBiIAsWJIkCEFIh/BoLJaiR2AFn0czJS8xf0SiC+amDymT9atGSJ6K4hDlaCStfIqYkoIq2E8FS",
+	"305f84sIW9AZVK4x98xVu5JOwOEjpxJ2h1TGRIE/RyEekuPDGvimgLJ8WYXJ7EZ0TmjyXzwx1YdqGeNs",
+	"4ZLcU3PfJZFAJEd1j5vB6kRyw8WsY+3ezmLZntLIX7az6W6cmA/dobQxmScLGrXRNdZGtn6v3sl2tYf8",
+	"D7GjEbVPnqJmv+CURsbLtXohizblTPWoGiiLc9s527JiqUe6kfJG2d8CxJLEPAyRfPbVYmtt3XOKTO20",
+	"Hc8VpTJJqqyp2bG29WHtFe9ChzNXlivmcRKmvc0q53d1c4EOOTYaRq/CJAH0/Fok4sX1SvOXUi2POAct",
+	"727VBSXtmlcvKWXH5xwWoNtQKZ5eQ4NCAwW4A7HUed8lT+ytdv1YNwTTET9paJZaauYwmqAlodGy8GZ2",
+	"zRH1ed5yLcefBbNDfgIScCIALT2IApJIOtMHX8LiDkTmPz+qAXXkzJzfHEVlUb6aR9JTmp01pwo9LM+1",
+	"Uotm7Z1cMhyiOSqooEip8x8OyO5ed5ecCT4JYUEOrYZFCaPt3PHZsf18AEORlzaQ1/YImaYhPSJ9iKhg",
+	"XNa0otHZDUQbfI1Dau8v5vUA3Ehl7puiax9yBtMQu/VYhPrP5aCOyafzYyJSCeW8VJktvtmiubAMYEp1",
+	"sy4vj3eWwfhzGhNtG8YufawhAGVKLFCxpNfTZctg2+gVLZOYEVogU78GB+vSeobvoHDIjdLCeSyvqM+S",
+	"9tzMjfUWYVlIUItChkJWJSLKJ6sIyQ75cxrZNRsSoCOstKCA9XOEM8wiUFbiFiD7ycKyoOLWpOFELsTT",
+	"0/8SGyiUcxrC4A+Wdi4PzR7ROnfJOReqVWVt2yOtwkXa1Oh4jfu1NOHdR6auiC6zjWKXk7Q5XLkTvzPH",
+	"ry+55nSw6qtIqZJHbYgOrqY36Qz4V6PE/7r+cU1uyqz6jMcK8atxsE7uXqSR/+YpqnG0JLe26thWHGdy",
+	"x4RVTFcSy9zlL4FE8HXFx3zsbVoDkAPk/0xALE9oNEvoDF4ssvB3XMVhUjhjB0VKmIGugEKlDV8hqvKX",
+	"o0vnGS1+MeB32DbxKZ+feUK3xWo+xv1NmvSLP6vQlX0/qC4KVGZMa15F145FpjET2tWrjOqQmx4v2cwo",
+	"pnUiWHveEbcaA8U4KKfd/W9Kw5wWWB9zkU/m1EWZ7imJk4DJOKRLCNb5E2m4pS6yQ7hzeav6ljea80wt",
+	"iX4nS3UbIIp6lkVTjlerqMA4QP0EYwMvfLd9R3XbI32Hye72BOc+NhMUf/opnQwFDXxVbhDxiY1J1PBQ",
+	"ALQQjRj2+o+qUlyuZTFT0CIpfRxHzkjSMZ6d4+DFZDE185c+seVO9z8qndOprlZvJnVwfoSlLu58sW3Z",
+	"xilNu2Q3eU8DfJrdoSukEvEyndfyPp5eXqf//ovunnB+ffl+/LHy5/XpefbaydHFRfpO9u/8havHO3Cv",
+	"J0mxJ7dzG81o9RuTiReQ/hz44BvvXkLkw2+MhdiC4WLBs/OjC5OyRiZM/3om9qkt3AxtugPgH4Vz8puw",
+	"zdPhm3JbtsZq/D3SpdCEiQoFqY3uXlRuZL9ekfgNrki0XGr3PZfK3UErD0Hbsj/ug8xa3xk2aFu/ECOU",
+	"aBPj/0+5uKcCM4tM5cFhXJhkAJI0/FViDIQvMN0Ys021NQjVv9td5/VZodiU+mpdxb99pVDrWAhqFz6O",
+	"YYOz2U5XXPfx/qbLNtsCkGk7/81iJJjuDddegBLMlx1f3q3p1fFCkGHjjhpspn9HDbom3TweWqVT6zDl",
+	"oZo2qDTTCJj0+Z1pXcpFcQdYZIbt4x75RInpom3LmOzIyt2rF5IkjVvHFL4a06wdymZ11msJ3u3t0wkd",
+	"9tvD4V7Q3g22t9uT3f1Be7+/N9ifDvxBb9hvUlDrlgbHtdrgZorA8U2X55GKmxYC88h82SOVXMb5u59X",
+	"GwDWS4Ft0iqtQA2YLgWuvUbe3pio2H98R96Yqtg3WiDmv9oa2TfvbN2EBoxJcoP10zdlWfiDmV9xmxrU",
+	"X4bNrxW8bdJrgMfvVmPK5lHqCDtNO/PlocIU1rS6YT0yH0VovbjaNrApVldb/sr2MyLwlWUfFu6RtygT",
+	"vvtiC6L32/39y15/tN0bDfo/f/GMc/+dXpEs5Oy7L/lBJwEHqVeEr0yqL54zV8IT4a9Qi+YZecs60NEJ",
+	"3VTPZWR4V2kWa0vpVRoz4qa7cBkTWOJCbFlpr9fpdzt7O53+cDja7+4PtjbQ9df6c/yxgJiKTerAL8r1",
+	"33mwF2I3ySuUrBsohSWbhZQqNmipYa96pEb8STJpw0/drITPpRLNQ63u6FrhncWrvOOPP5x6aWjIew9h",
+	"yLHfdBj8R92Ay2JcddVtI0uNN4TtUl+uis9qlE1dPz1utaNSgbeMfZ1FsFl3m8VD4wPPqkxiEJXvd00A",
+	"33IxyEs1NGr6bZTVlZaPfQ9lZSLlsYEro34NB66LsG0+xT88uhY42XyWWhyh2RTVU9VsFAq00qjVR6D6",
+	"6rMeW1zzeYMRZkbXdsrN2Z1KN5F5C3aTRl+ZFmgRHoXLvG5y6mh3mE5SFsOw0JUgGFn/v7my9fki7+L/",
+	"gX4lHxKpQCxoFD3tqwdlRNtFvz2ltzUuXtyC9/9oBOSQO9V+8TMOjtXyjdRcEvukuGqLpJddKq5KVjpc",
+	"gqs3oHf93cFuezLo7bW3u9t7bbq7H7S3d/f2g53t4cQf9Mopjf5w+GhpbwpxnafwVZ9HMrF5mdoX3tGp",
+	"w/wMKlLmg/0mfL4VJPXxZYHu5pZx/pl1u0tSzN8R0+U/K0Pzep1up2vDgBGNmTfyBp1ep2vuEsxlehfa",
+	"3CBaBerD/wwABReW5xaLAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
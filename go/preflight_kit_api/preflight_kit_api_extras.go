@@ -1,3 +1,121 @@
 package preflight_kit_api
 
-type ExecutionModification any
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Headers of the callback requests carrying a StatusCallbackRequestBody, see PreflightCallback.
+const (
+	CallbackSignatureHeader = "X-Steadybit-Signature"
+	CallbackTimestampHeader = "X-Steadybit-Timestamp"
+)
+
+// maxPropertyKeyLength is the maxLength of propertyKey in the spec.
+const maxPropertyKeyLength = 64
+
+// NewSetPropertyValue creates a modification setting the value of a property.
+func NewSetPropertyValue(propertyKey string, value any) (ExecutionModification, error) {
+	var modification ExecutionModification
+	err := modification.FromExecutionModificationSetPropertyValue(ExecutionModificationSetPropertyValue{
+		PropertyKey: propertyKey,
+		Value:       value,
+	})
+	return modification, err
+}
+
+// NewAddValueToListProperty creates a modification adding a value to a list property.
+func NewAddValueToListProperty(propertyKey string, value any) (ExecutionModification, error) {
+	var modification ExecutionModification
+	err := modification.FromExecutionModificationAddValueToListProperty(ExecutionModificationAddValueToListProperty{
+		PropertyKey: propertyKey,
+		Value:       value,
+	})
+	return modification, err
+}
+
+// ExecutionModificationOf converts a modification of the type ExecutionModification had before 1.5.0, which was any:
+// a map or struct encoding to a modification like {"type": "set_property_value", "propertyKey": "key", "value": 1}.
+// It fails for values which don't encode to a valid modification.
+//
+// Deprecated: Use NewSetPropertyValue or NewAddValueToListProperty.
+func ExecutionModificationOf(value any) (ExecutionModification, error) {
+	var modification ExecutionModification
+	body, err := json.Marshal(value)
+	if err != nil {
+		return modification, err
+	}
+	if err := modification.UnmarshalJSON(body); err != nil {
+		return modification, err
+	}
+	return modification, modification.Validate()
+}
+
+// AsMap returns the modification as the map it was decoded to before 1.5.0, when ExecutionModification was any.
+//
+// Deprecated: Use Switch or ValueByDiscriminator.
+func (t ExecutionModification) AsMap() (map[string]any, error) {
+	body, err := t.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	err = json.Unmarshal(body, &result)
+	return result, err
+}
+
+// ExecutionModificationCases handles each type of ExecutionModification, see ExecutionModification.Switch.
+type ExecutionModificationCases struct {
+	SetPropertyValue       func(ExecutionModificationSetPropertyValue) error
+	AddValueToListProperty func(ExecutionModificationAddValueToListProperty) error
+}
+
+// Switch decodes and validates the modification and calls the case of its type. It fails for unknown types, invalid
+// modifications and types without a case.
+func (t ExecutionModification) Switch(cases ExecutionModificationCases) error {
+	value, err := t.ValueByDiscriminator()
+	if err != nil {
+		return err
+	}
+	switch modification := value.(type) {
+	case ExecutionModificationSetPropertyValue:
+		if err := validateModification(modification.PropertyKey, modification.Value); err != nil {
+			return fmt.Errorf("invalid %s modification: %w", SetPropertyValue, err)
+		}
+		if cases.SetPropertyValue == nil {
+			return fmt.Errorf("unhandled modification type: %s", SetPropertyValue)
+		}
+		return cases.SetPropertyValue(modification)
+	case ExecutionModificationAddValueToListProperty:
+		if err := validateModification(modification.PropertyKey, modification.Value); err != nil {
+			return fmt.Errorf("invalid %s modification: %w", AddValueToListProperty, err)
+		}
+		if cases.AddValueToListProperty == nil {
+			return fmt.Errorf("unhandled modification type: %s", AddValueToListProperty)
+		}
+		return cases.AddValueToListProperty(modification)
+	}
+	return fmt.Errorf("unhandled modification: %T", value)
+}
+
+// Validate checks that the modification has a known type, a property key and a value.
+func (t ExecutionModification) Validate() error {
+	return t.Switch(ExecutionModificationCases{
+		SetPropertyValue:       func(ExecutionModificationSetPropertyValue) error { return nil },
+		AddValueToListProperty: func(ExecutionModificationAddValueToListProperty) error { return nil },
+	})
+}
+
+func validateModification(propertyKey string, value any) error {
+	if propertyKey == "" {
+		return errors.New("propertyKey is missing")
+	}
+	if len(propertyKey) > maxPropertyKeyLength {
+		return fmt.Errorf("propertyKey is longer than %d characters", maxPropertyKeyLength)
+	}
+	if value == nil {
+		return errors.New("value is missing")
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package preflight_kit_api
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -333,10 +335,23 @@ func TestPreflightKitObjects(t *testing.T) {
 		sprb := StartPreflightRequestBody{
 			ExperimentExecution:        ee,
 			PreflightActionExecutionId: id,
+			Callback: &PreflightCallback{
+				Url:    "https://agent.example.com/preflights/callback",
+				Secret: "secret",
+			},
 		}
 		markAsUsed(t, sprb)
 	})
 
+	// StatusCallbackRequestBody
+	t.Run("StatusCallbackRequestBody", func(t *testing.T) {
+		scrb := StatusCallbackRequestBody{
+			PreflightActionExecutionId: uuid.New(),
+			Result:                     StatusResult{Completed: true},
+		}
+		markAsUsed(t, scrb)
+	})
+
 	// Union response types (empty union data)
 	t.Run("CancelPreflightResponse", func(t *testing.T) {
 		cpr := CancelPreflightResponse{}
@@ -405,3 +420,80 @@ func TestPreflightKitObjects(t *testing.T) {
 	})
 
 }
+
+func TestExecutionModification(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		set, err := NewSetPropertyValue("example-property", "example-value")
+		assert.NoError(t, err)
+		add, err := NewAddValueToListProperty("example-list", 42)
+		assert.NoError(t, err)
+
+		body, err := json.Marshal(StatusResult{Completed: true, Modifications: &ExecutionModifications{set, add}})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+  "completed": true,
+  "modifications": [
+    {"type": "set_property_value", "propertyKey": "example-property", "value": "example-value"},
+    {"type": "add_value_to_list_property", "propertyKey": "example-list", "value": 42}
+  ]
+}`, string(body))
+
+		var decoded StatusResult
+		assert.NoError(t, json.Unmarshal(body, &decoded))
+		var seen []string
+		for _, modification := range *decoded.Modifications {
+			assert.NoError(t, modification.Switch(ExecutionModificationCases{
+				SetPropertyValue: func(m ExecutionModificationSetPropertyValue) error {
+					seen = append(seen, m.PropertyKey+"="+m.Value.(string))
+					return nil
+				},
+				AddValueToListProperty: func(m ExecutionModificationAddValueToListProperty) error {
+					seen = append(seen, m.PropertyKey+"+="+fmt.Sprint(m.Value))
+					return nil
+				},
+			}))
+		}
+		assert.Equal(t, []string{"example-property=example-value", "example-list+=42"}, seen)
+	})
+
+	t.Run("invalid modifications", func(t *testing.T) {
+		for body, expected := range map[string]string{
+			`{"type": "remove_property", "propertyKey": "example-property"}`:                               "unknown discriminator value: remove_property",
+			`{"propertyKey": "example-property", "value": "example-value"}`:                                "unknown discriminator value: ",
+			`{"type": "set_property_value", "value": "example-value"}`:                                     "invalid set_property_value modification: propertyKey is missing",
+			`{"type": "add_value_to_list_property", "propertyKey": "example-list"}`:                        "invalid add_value_to_list_property modification: value is missing",
+			`{"type": "set_property_value", "propertyKey": 42, "value": "example"}`:                        "cannot unmarshal number",
+			`{"type": "set_property_value", "propertyKey": "` + strings.Repeat("k", 65) + `", "value": 1}`: "propertyKey is longer than 64 characters",
+		} {
+			var modification ExecutionModification
+			assert.NoError(t, json.Unmarshal([]byte(body), &modification))
+			assert.ErrorContains(t, modification.Validate(), expected, body)
+		}
+	})
+
+	t.Run("migration from any", func(t *testing.T) {
+		modification, err := ExecutionModificationOf(map[string]any{"type": "set_property_value", "propertyKey": "example-property", "value": "example-value"})
+		assert.NoError(t, err)
+		expected, err := NewSetPropertyValue("example-property", "example-value")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, modification)
+
+		asMap, err := modification.AsMap()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"type": "set_property_value", "propertyKey": "example-property", "value": "example-value"}, asMap)
+
+		_, err = ExecutionModificationOf(map[string]any{"propertyKey": "example-property", "value": "example-value"})
+		assert.ErrorContains(t, err, "unknown discriminator value")
+		_, err = ExecutionModificationOf(func() {})
+		assert.Error(t, err)
+	})
+
+	t.Run("unhandled type", func(t *testing.T) {
+		modification, err := NewAddValueToListProperty("example-list", "value")
+		assert.NoError(t, err)
+		err = modification.Switch(ExecutionModificationCases{
+			SetPropertyValue: func(ExecutionModificationSetPropertyValue) error { return nil },
+		})
+		assert.EqualError(t, err, "unhandled modification type: add_value_to_list_property")
+	})
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	adminPath       = "/admin/preflights/"
+	redactedValue   = "[REDACTED]"
+	adminCancelText = "canceled by admin"
+)
+
+// secretKeyParts identify the state values which are redacted by the admin endpoints.
+var secretKeyParts = []string{"password", "secret", "token", "credential", "apikey", "api_key", "privatekey", "private_key", "authorization"}
+
+type adminExecution struct {
+	PreflightActionExecutionId uuid.UUID         `json:"preflightActionExecutionId"`
+	PreflightActionId          string            `json:"preflightActionId"`
+	Labels                     map[string]string `json:"labels,omitempty"`
+	Heartbeat                  *adminHeartbeat   `json:"heartbeat,omitempty"`
+	State                      map[string]any    `json:"state,omitempty"`
+	Error                      string            `json:"error,omitempty"`
+}
+
+type adminHeartbeat struct {
+	Interval      string    `json:"interval"`
+	Timeout       string    `json:"timeout"`
+	LastHeartbeat time.Time `json:"lastHeartbeat"`
+}
+
+type adminStopEvent struct {
+	PreflightActionExecutionId uuid.UUID `json:"preflightActionExecutionId"`
+	Reason                     string    `json:"reason"`
+	Timestamp                  time.Time `json:"timestamp"`
+}
+
+// RegisterAdminEndpoints registers endpoints for operators to inspect and force-cancel active executions:
+//   - GET /admin/preflights/executions lists the active executions with their heartbeat,
+//   - GET /admin/preflights/executions/{executionId} shows an execution's state with secrets redacted,
+//   - GET /admin/preflights/stop-events lists the recent stop events,
+//   - POST /admin/preflights/executions/{executionId}/cancel cancels an execution like [CancelPreflight].
+//
+// The endpoints require the authentication set by [SetAuthentication] and are rejected without one.
+func RegisterAdminEndpoints() {
+	registerHttpHandler(adminPath, adminHandler())
+}
+
+func adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+adminPath+"executions", handleAdminListExecutions)
+	mux.HandleFunc("GET "+adminPath+"executions/{executionId}", handleAdminGetExecution)
+	mux.HandleFunc("POST "+adminPath+"executions/{executionId}/cancel", handleAdminCancelExecution)
+	mux.HandleFunc("GET "+adminPath+"stop-events", handleAdminListStopEvents)
+	return requireConfiguredAuthentication(mux)
+}
+
+// requireConfiguredAuthentication rejects all requests unless an authentication is set, as the admin endpoints must
+// never be open.
+func requireConfiguredAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a := authentication.Load(); a == nil || !a.enabled() {
+			writeProblem(w, authFailure{status: http.StatusForbidden, reason: "The admin endpoints require an authentication, see SetAuthentication."})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func handleAdminListExecutions(w http.ResponseWriter, r *http.Request) {
+	ids, err := statePersister.GetExecutionIds(r.Context())
+	if err != nil {
+		writeResponse(w, *errorResponse(http.StatusInternalServerError, "Failed to load active executions.", err))
+		return
+	}
+	executions := make([]adminExecution, 0, len(ids))
+	for _, id := range ids {
+		execution := adminExecution{PreflightActionExecutionId: id, Heartbeat: heartbeatOf(id)}
+		if persistedState, err := statePersister.GetState(r.Context(), id); err != nil {
+			// the execution might have ended in the meantime
+			execution.Error = err.Error()
+		} else {
+			execution.PreflightActionId = persistedState.PreflightActionId
+			execution.Labels = persistedState.Labels
+		}
+		executions = append(executions, execution)
+	}
+	slices.SortFunc(executions, func(a, b adminExecution) int {
+		return strings.Compare(a.PreflightActionExecutionId.String(), b.PreflightActionExecutionId.String())
+	})
+	writeResponse(w, resultResponse(executions))
+}
+
+func handleAdminGetExecution(w http.ResponseWriter, r *http.Request) {
+	id, failure := executionIdFromPath(r)
+	if failure != nil {
+		writeResponse(w, *failure)
+		return
+	}
+	persistedState, err := statePersister.GetState(r.Context(), id)
+	if err != nil {
+		writeResponse(w, *errorResponse(http.StatusNotFound, "Execution not found.", err))
+		return
+	}
+	writeResponse(w, resultResponse(adminExecution{
+		PreflightActionExecutionId: id,
+		PreflightActionId:          persistedState.PreflightActionId,
+		Labels:                     persistedState.Labels,
+		Heartbeat:                  heartbeatOf(id),
+		State:                      redact(map[string]any(persistedState.State)).(map[string]any),
+	}))
+}
+
+func handleAdminCancelExecution(w http.ResponseWriter, r *http.Request) {
+	id, failure := executionIdFromPath(r)
+	if failure != nil {
+		writeResponse(w, *failure)
+		return
+	}
+	if _, queued := admission.queuedStart(id); !queued {
+		if _, err := statePersister.GetState(r.Context(), id); err != nil {
+			writeResponse(w, *errorResponse(http.StatusNotFound, "Execution not found.", err))
+			return
+		}
+	}
+
+	log.Warn().
+		Str("audit", "admin").
+		Str("remoteAddr", r.RemoteAddr).
+		Str("preflightActionExecutionId", id.String()).
+		Msg("force-canceling preflight")
+	// the cancellation must not be aborted, if the operator's request is
+	CancelPreflight(context.WithoutCancel(r.Context()), id, adminCancelText)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleAdminListStopEvents(w http.ResponseWriter, _ *http.Request) {
+	events := recentStopEvents()
+	result := make([]adminStopEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, adminStopEvent{
+			PreflightActionExecutionId: event.preflightActionExecutionId,
+			Reason:                     event.reason,
+			Timestamp:                  event.timestamp,
+		})
+	}
+	writeResponse(w, resultResponse(result))
+}
+
+func executionIdFromPath(r *http.Request) (uuid.UUID, *response) {
+	id, err := uuid.Parse(r.PathValue("executionId"))
+	if err != nil {
+		return uuid.Nil, errorResponse(http.StatusBadRequest, "Invalid execution id.", err)
+	}
+	return id, nil
+}
+
+func heartbeatOf(preflightActionExecutionId uuid.UUID) *adminHeartbeat {
+	monitor, ok := heartbeatMonitors.Load(preflightActionExecutionId)
+	if !ok {
+		return nil
+	}
+	m := monitor.(*heartbeatMonitor)
+	return &adminHeartbeat{
+		Interval:      m.interval.String(),
+		Timeout:       m.timeout.String(),
+		LastHeartbeat: m.lastHeartbeat(),
+	}
+}
+
+// redact replaces the values of keys which look like secrets, e.g. "password" or "apiToken".
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, nested := range v {
+			if isSecretKey(key) {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redact(nested)
+			}
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, nested := range v {
+			redacted[i] = redact(nested)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(secretKeyParts, func(part string) bool {
+		return strings.Contains(key, part)
+	})
+}
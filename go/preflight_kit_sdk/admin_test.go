@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveAdmin(t *testing.T, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(method, path, nil)
+	request.Header.Set("Authorization", "Bearer admin-token")
+	recorder := httptest.NewRecorder()
+	adminHandler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func persistAdminExecution(t *testing.T, state preflight_kit_api.PreflightState) uuid.UUID {
+	executionId := uuid.New()
+	require.NoError(t, statePersister.PersistState(context.Background(), &state_persister.PersistedState{
+		PreflightActionExecutionId: executionId,
+		PreflightActionId:          "ExamplePreflightId",
+		State:                      state,
+		Labels:                     map[string]string{"experimentKey": "ADM-1"},
+	}))
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+	return executionId
+}
+
+func Test_admin_requires_authentication(t *testing.T) {
+	recorder := serveAdmin(t, http.MethodGet, "/admin/preflights/executions")
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "the admin endpoints are never open")
+
+	useAuthentication(t, Authentication{Tokens: map[string]string{"operator": "admin-token"}})
+	request := httptest.NewRequest(http.MethodGet, "/admin/preflights/executions", nil)
+	recorder = httptest.NewRecorder()
+	authenticated(adminHandler()).ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func Test_admin_lists_executions(t *testing.T) {
+	useAuthentication(t, Authentication{Tokens: map[string]string{"operator": "admin-token"}})
+	executionId := persistAdminExecution(t, preflight_kit_api.PreflightState{"foo": "bar"})
+	monitorHeartbeat(executionId, time.Hour, time.Hour)
+	t.Cleanup(func() { stopMonitorHeartbeat(executionId) })
+
+	recorder := serveAdmin(t, http.MethodGet, "/admin/preflights/executions")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var executions []adminExecution
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &executions))
+	var found *adminExecution
+	for i := range executions {
+		if executions[i].PreflightActionExecutionId == executionId {
+			found = &executions[i]
+		}
+	}
+	require.NotNil(t, found)
+	assert.Equal(t, "ExamplePreflightId", found.PreflightActionId)
+	assert.Equal(t, map[string]string{"experimentKey": "ADM-1"}, found.Labels)
+	assert.Nil(t, found.State, "the list doesn't show states")
+	if assert.NotNil(t, found.Heartbeat) {
+		assert.Equal(t, "1h0m0s", found.Heartbeat.Timeout)
+	}
+}
+
+func Test_admin_shows_execution_with_redacted_secrets(t *testing.T) {
+	useAuthentication(t, Authentication{Tokens: map[string]string{"operator": "admin-token"}})
+	executionId := persistAdminExecution(t, preflight_kit_api.PreflightState{
+		"cluster":  "prod",
+		"apiToken": "very-secret",
+		"auth": map[string]any{
+			"username": "admin",
+			"Password": "hunter2",
+		},
+		"targets": []any{map[string]any{"name": "a", "client_secret": "x"}},
+	})
+
+	recorder := serveAdmin(t, http.MethodGet, "/admin/preflights/executions/"+executionId.String())
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var execution adminExecution
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &execution))
+	assert.Equal(t, map[string]any{
+		"cluster":  "prod",
+		"apiToken": redactedValue,
+		"auth": map[string]any{
+			"username": "admin",
+			"Password": redactedValue,
+		},
+		"targets": []any{map[string]any{"name": "a", "client_secret": redactedValue}},
+	}, execution.State)
+	assert.NotContains(t, recorder.Body.String(), "hunter2")
+
+	assert.Equal(t, http.StatusNotFound, serveAdmin(t, http.MethodGet, "/admin/preflights/executions/"+uuid.NewString()).Code)
+	assert.Equal(t, http.StatusBadRequest, serveAdmin(t, http.MethodGet, "/admin/preflights/executions/not-a-uuid").Code)
+}
+
+func Test_admin_force_cancels_execution(t *testing.T) {
+	useAuthentication(t, Authentication{Tokens: map[string]string{"operator": "admin-token"}})
+	calls := make(chan Call, 10)
+	registeredPreflights["ExamplePreflightId"] = NewExamplePreflight(calls)
+	t.Cleanup(func() { delete(registeredPreflights, "ExamplePreflightId") })
+	executionId := persistAdminExecution(t, preflight_kit_api.PreflightState{"foo": "bar"})
+
+	assert.Equal(t, http.StatusMethodNotAllowed, serveAdmin(t, http.MethodGet, "/admin/preflights/executions/"+executionId.String()+"/cancel").Code)
+	recorder := serveAdmin(t, http.MethodPost, "/admin/preflights/executions/"+executionId.String()+"/cancel")
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+
+	assert.Equal(t, "Cancel", (<-calls).Name)
+	_, err := statePersister.GetState(context.Background(), executionId)
+	assert.Error(t, err, "the state is deleted")
+	assert.Equal(t, http.StatusNotFound, serveAdmin(t, http.MethodPost, "/admin/preflights/executions/"+executionId.String()+"/cancel").Code)
+
+	recorder = serveAdmin(t, http.MethodGet, "/admin/preflights/stop-events")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var events []adminStopEvent
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &events))
+	require.NotEmpty(t, events)
+	assert.Equal(t, executionId, events[len(events)-1].PreflightActionExecutionId)
+	assert.Equal(t, adminCancelText, events[len(events)-1].Reason)
+}
@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// AdmissionPolicy decides what happens to calls exceeding a concurrency limit.
+type AdmissionPolicy string
+
+const (
+	// AdmissionReject fails over-limit calls with a [preflight_kit_api.PreflightKitError].
+	AdmissionReject AdmissionPolicy = "reject"
+	// AdmissionQueue defers over-limit starts until capacity is available, while the status reports that the execution
+	// is waiting for capacity. Over-limit calls wait for a free call slot.
+	AdmissionQueue AdmissionPolicy = "queue"
+)
+
+// Limits restrict how many executions may be active and how many Start and Status calls may run at the same time.
+// A zero value means unlimited. Cancel calls are never limited.
+type Limits struct {
+	// MaxActiveExecutions is the number of executions which may be active at the same time. An execution is active from
+	// its start until it has completed, failed or has been canceled.
+	MaxActiveExecutions int
+	// MaxInflightCalls is the number of Start and Status calls which may run at the same time.
+	MaxInflightCalls int
+	// Admission decides what happens to calls exceeding the limits. Defaults to the global admission policy, or
+	// [AdmissionReject] if none is set.
+	Admission AdmissionPolicy
+	// IdleTimeout frees the slot of an active execution and drops a queued start, if the agent hasn't called start or
+	// status of the execution for this long, e.g. because it went away without canceling. Defaults to ten status call
+	// intervals, but at least 10 minutes.
+	IdleTimeout time.Duration
+	// MaxCallSlotWait bounds how long calls wait for a free call slot with [AdmissionQueue]. Defaults to 30 seconds.
+	MaxCallSlotWait time.Duration
+}
+
+// WithLimits restricts the concurrency of the preflight. The global limits set by [SetGlobalLimits] apply in addition.
+func WithLimits(limits Limits) PreflightOption {
+	return func(o *preflightOptions) {
+		o.limits = limits
+	}
+}
+
+// SetGlobalLimits restricts the concurrency of all registered preflights combined.
+func SetGlobalLimits(limits Limits) {
+	admission.mu.Lock()
+	defer admission.mu.Unlock()
+	admission.globalLimits = limits
+	admission.globalCallSlots = newSemaphore(limits.MaxInflightCalls)
+}
+
+const (
+	waitingForCapacity     = "Waiting for capacity to start the preflight."
+	defaultIdleTimeout     = 10 * time.Minute
+	defaultMaxCallSlotWait = 30 * time.Second
+)
+
+var (
+	admission     = newAdmissionController()
+	errNoCapacity = errors.New("no capacity available")
+)
+
+// admissionController keeps track of the active and queued executions of all preflights.
+type admissionController struct {
+	mu                sync.Mutex
+	globalLimits      Limits
+	globalCallSlots   semaphore
+	active            map[uuid.UUID]string
+	activeByPreflight map[string]int
+	queued            map[uuid.UUID]preflight_kit_api.StartPreflightRequestBody
+	// idleDeadlines of the active and queued executions, after which they are released or dropped.
+	idleDeadlines map[uuid.UUID]time.Time
+}
+
+func newAdmissionController() *admissionController {
+	return &admissionController{
+		active:            make(map[uuid.UUID]string),
+		activeByPreflight: make(map[string]int),
+		queued:            make(map[uuid.UUID]preflight_kit_api.StartPreflightRequestBody),
+		idleDeadlines:     make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (c *admissionController) globalSlots() semaphore {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.globalCallSlots
+}
+
+func (c *admissionController) policy(limits Limits) AdmissionPolicy {
+	if limits.Admission != "" {
+		return limits.Admission
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.globalLimits.Admission != "" {
+		return c.globalLimits.Admission
+	}
+	return AdmissionReject
+}
+
+// tryActivate marks the execution as active, unless the preflight's or the global limit of active executions is
+// reached. Idle executions are released first.
+func (c *admissionController) tryActivate(preflightId string, preflightActionExecutionId uuid.UUID, limits Limits, idleTimeout time.Duration) bool {
+	c.expireIdle()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.active[preflightActionExecutionId]; ok {
+		c.idleDeadlines[preflightActionExecutionId] = currentClock().Now().Add(idleTimeout)
+		return true
+	}
+	if limits.MaxActiveExecutions > 0 && c.activeByPreflight[preflightId] >= limits.MaxActiveExecutions {
+		return false
+	}
+	if c.globalLimits.MaxActiveExecutions > 0 && len(c.active) >= c.globalLimits.MaxActiveExecutions {
+		return false
+	}
+	c.active[preflightActionExecutionId] = preflightId
+	c.activeByPreflight[preflightId]++
+	c.idleDeadlines[preflightActionExecutionId] = currentClock().Now().Add(idleTimeout)
+	return true
+}
+
+// touch extends the idle deadline of an active or queued execution.
+func (c *admissionController) touch(preflightActionExecutionId uuid.UUID, idleTimeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.idleDeadlines[preflightActionExecutionId]; ok {
+		c.idleDeadlines[preflightActionExecutionId] = currentClock().Now().Add(idleTimeout)
+	}
+}
+
+// expireIdle releases the active and drops the queued executions which have passed their idle deadline. They are
+// marked as stopped, so a late status call reports the execution as stopped instead of running it without a slot.
+func (c *admissionController) expireIdle() {
+	now := currentClock().Now()
+	c.mu.Lock()
+	var expired []uuid.UUID
+	for preflightActionExecutionId, deadline := range c.idleDeadlines {
+		if now.Before(deadline) {
+			continue
+		}
+		expired = append(expired, preflightActionExecutionId)
+		delete(c.idleDeadlines, preflightActionExecutionId)
+		delete(c.queued, preflightActionExecutionId)
+		if preflightId, ok := c.active[preflightActionExecutionId]; ok {
+			delete(c.active, preflightActionExecutionId)
+			c.activeByPreflight[preflightId]--
+		}
+	}
+	c.mu.Unlock()
+
+	for _, preflightActionExecutionId := range expired {
+		log.Info().
+			Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+			Msg("execution has been idle for too long, releasing its capacity")
+		markAsStopped(preflightActionExecutionId, "idle timeout")
+		forgetExecutionMetrics(preflightActionExecutionId)
+		forgetCallback(preflightActionExecutionId)
+		forgetModifications(preflightActionExecutionId)
+		forgetLabels(preflightActionExecutionId)
+		statusStreams.end(preflightActionExecutionId, streamEndCanceled, "idle timeout")
+	}
+}
+
+// release frees the slot of an active execution. It is safe to release an execution multiple times.
+func (c *admissionController) release(preflightActionExecutionId uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if preflightId, ok := c.active[preflightActionExecutionId]; ok {
+		delete(c.active, preflightActionExecutionId)
+		c.activeByPreflight[preflightId]--
+	}
+	if _, ok := c.queued[preflightActionExecutionId]; !ok {
+		delete(c.idleDeadlines, preflightActionExecutionId)
+	}
+}
+
+func (c *admissionController) enqueue(request preflight_kit_api.StartPreflightRequestBody, idleTimeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queued[request.PreflightActionExecutionId] = request
+	c.idleDeadlines[request.PreflightActionExecutionId] = currentClock().Now().Add(idleTimeout)
+}
+
+// tracks reports whether the execution is active or queued.
+func (c *admissionController) tracks(preflightActionExecutionId uuid.UUID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, active := c.active[preflightActionExecutionId]
+	_, queued := c.queued[preflightActionExecutionId]
+	return active || queued
+}
+
+func (c *admissionController) queuedStart(preflightActionExecutionId uuid.UUID) (preflight_kit_api.StartPreflightRequestBody, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	request, ok := c.queued[preflightActionExecutionId]
+	return request, ok
+}
+
+// dequeue removes a queued start and reports whether the execution was queued.
+func (c *admissionController) dequeue(preflightActionExecutionId uuid.UUID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.queued[preflightActionExecutionId]
+	delete(c.queued, preflightActionExecutionId)
+	if _, active := c.active[preflightActionExecutionId]; !active {
+		delete(c.idleDeadlines, preflightActionExecutionId)
+	}
+	return ok
+}
+
+// acquireCallSlot reserves a slot for a Start or Status call within the preflight's and the global limit. Depending on
+// the admission policy it waits for a free slot, up to MaxCallSlotWait, or fails right away. The returned function
+// frees the slot again.
+func (a *preflightHttpAdapter[T]) acquireCallSlot(ctx context.Context) (func(), *preflight_kit_api.PreflightKitError) {
+	global := admission.globalSlots()
+	wait := admission.policy(a.options.limits) == AdmissionQueue
+	if wait {
+		maxWait := a.options.limits.MaxCallSlotWait
+		if maxWait <= 0 {
+			maxWait = defaultMaxCallSlotWait
+		}
+		// the deadline follows the SDK's clock, unlike context.WithTimeout
+		var cancel context.CancelCauseFunc
+		ctx, cancel = context.WithCancelCause(ctx)
+		defer cancel(nil)
+		timer := currentClock().NewTimer(maxWait)
+		defer timer.Stop()
+		go func() {
+			select {
+			case <-timer.C():
+				cancel(errNoCapacity)
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	if err := a.callSlots.acquire(ctx, wait); err != nil {
+		return nil, capacityExhaustedError(fmt.Sprintf("Too many in-flight calls of preflight %s.", a.description.Id), err)
+	}
+	if err := global.acquire(ctx, wait); err != nil {
+		a.callSlots.release()
+		return nil, capacityExhaustedError("Too many in-flight preflight calls.", err)
+	}
+	return func() {
+		global.release()
+		a.callSlots.release()
+	}, nil
+}
+
+// idleTimeout returns after how long without start or status calls an execution's capacity is released.
+func (a *preflightHttpAdapter[T]) idleTimeout() time.Duration {
+	if a.options.limits.IdleTimeout > 0 {
+		return a.options.limits.IdleTimeout
+	}
+	timeout := defaultIdleTimeout
+	if a.description.Status.CallInterval != nil {
+		if callInterval, err := parseCallInterval(*a.description.Status.CallInterval); err == nil && 10*callInterval > timeout {
+			timeout = 10 * callInterval
+		}
+	}
+	return timeout
+}
+
+func capacityExhaustedError(detail string, err error) *preflight_kit_api.PreflightKitError {
+	if !errors.Is(err, errNoCapacity) {
+		detail = fmt.Sprintf("%s %s", detail, err.Error())
+	}
+	return &preflight_kit_api.PreflightKitError{
+		Title:  "Preflight capacity exhausted.",
+		Detail: &detail,
+		Status: extutil.Ptr(preflight_kit_api.Errored),
+	}
+}
+
+// semaphore limits concurrent access. A nil semaphore is unlimited.
+type semaphore chan struct{}
+
+func newSemaphore(size int) semaphore {
+	if size <= 0 {
+		return nil
+	}
+	return make(semaphore, size)
+}
+
+func (s semaphore) acquire(ctx context.Context, wait bool) error {
+	if s == nil {
+		return nil
+	}
+	if !wait {
+		select {
+		case s <- struct{}{}:
+			return nil
+		default:
+			return errNoCapacity
+		}
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kit/exthttp"
+)
+
+// Authentication protects the endpoints of the preflights. A request has to pass all configured checks. The zero value
+// disables the authentication.
+type Authentication struct {
+	// Tokens are the accepted bearer tokens by the identity of the agent holding them.
+	Tokens map[string]string
+	// TokenFile is the path of a file with accepted bearer tokens, one `<identity>:<token>` or `<token>` per line. Lines
+	// are split at the first colon, so identities can't contain one, while tokens can: prefix a token containing a colon
+	// with its identity, or with a colon if it has none. Empty lines and lines starting with # are ignored. The file is
+	// read again once it has been modified, so tokens can be rotated without a restart.
+	TokenFile string
+	// RequireClientCertificate requires a client certificate, which has been verified by the TLS server. The accepted
+	// CAs are configured via STEADYBIT_EXTENSION_TLS_CLIENT_CAS, see [exthttp.ListenSpecification].
+	RequireClientCertificate bool
+	// AllowedIdentities restricts the access to these agent identities. The identity of a request is the one of its
+	// bearer token and the common name, DNS names and URIs of its verified client certificate.
+	AllowedIdentities []string
+}
+
+func (a Authentication) enabled() bool {
+	return len(a.Tokens) > 0 || a.TokenFile != "" || a.RequireClientCertificate || len(a.AllowedIdentities) > 0
+}
+
+var authentication atomic.Pointer[authenticator]
+
+// SetAuthentication protects the endpoints of all registered preflights and the handlers wrapped with
+// [RequireAuthentication]. It may be called before or after the preflights have been registered.
+func SetAuthentication(auth Authentication) error {
+	a := &authenticator{Authentication: auth}
+	if auth.TokenFile != "" {
+		a.tokenFile = &tokenFile{path: auth.TokenFile}
+		if _, err := a.tokenFile.load(); err != nil {
+			return err
+		}
+	}
+	authentication.Store(a)
+	return nil
+}
+
+// RequireAuthentication wraps a handler, e.g. the preflight list, with the authentication set by [SetAuthentication].
+func RequireAuthentication(handler exthttp.Handler) exthttp.Handler {
+	return func(w http.ResponseWriter, r *http.Request, body []byte) {
+		if authenticateRequest(w, r) {
+			handler(w, r, body)
+		}
+	}
+}
+
+func authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authenticateRequest(w, r) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// authenticateRequest checks the request and answers it with a problem, if it is rejected.
+func authenticateRequest(w http.ResponseWriter, r *http.Request) bool {
+	a := authentication.Load()
+	if a == nil || !a.enabled() {
+		return true
+	}
+	identities, failure := a.authenticate(r)
+	if failure != nil {
+		log.Warn().
+			Str("audit", "authentication").
+			Str("remoteAddr", r.RemoteAddr).
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Strs("identities", identities).
+			Int("status", failure.status).
+			Str("reason", failure.reason).
+			Msg("rejected preflight request")
+		writeProblem(w, *failure)
+		return false
+	}
+	log.Debug().
+		Str("audit", "authentication").
+		Str("remoteAddr", r.RemoteAddr).
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Strs("identities", identities).
+		Msg("authenticated preflight request")
+	return true
+}
+
+type authenticator struct {
+	Authentication
+	tokenFile *tokenFile
+}
+
+type authFailure struct {
+	status int
+	reason string
+}
+
+func (a *authenticator) authenticate(r *http.Request) ([]string, *authFailure) {
+	var identities []string
+
+	if len(a.Tokens) > 0 || a.tokenFile != nil {
+		identity, failure := a.authenticateToken(r)
+		if failure != nil {
+			return nil, failure
+		}
+		if identity != "" {
+			identities = append(identities, identity)
+		}
+	}
+
+	certificateIdentities, verified := clientCertificateIdentities(r)
+	if a.RequireClientCertificate && !verified {
+		return identities, &authFailure{status: http.StatusUnauthorized, reason: "A verified client certificate is required."}
+	}
+	identities = append(identities, certificateIdentities...)
+
+	if len(a.AllowedIdentities) > 0 && !slices.ContainsFunc(identities, func(identity string) bool {
+		return slices.Contains(a.AllowedIdentities, identity)
+	}) {
+		return identities, &authFailure{status: http.StatusForbidden, reason: "The identity is not allowed to call preflights."}
+	}
+	return identities, nil
+}
+
+func (a *authenticator) authenticateToken(r *http.Request) (string, *authFailure) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", &authFailure{status: http.StatusUnauthorized, reason: "A bearer token is required."}
+	}
+
+	var fileTokens []bearerToken
+	if a.tokenFile != nil {
+		var err error
+		if fileTokens, err = a.tokenFile.load(); err != nil {
+			log.Error().Err(err).Msg("Failed to load bearer tokens, rejecting all tokens from the file.")
+		}
+	}
+
+	// compare with all tokens, so that the timing does not reveal which token matched
+	identity, matched := "", false
+	check := func(candidateIdentity, candidate string) {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 && !matched {
+			identity, matched = candidateIdentity, true
+		}
+	}
+	for candidateIdentity, candidate := range a.Tokens {
+		check(candidateIdentity, candidate)
+	}
+	for _, candidate := range fileTokens {
+		check(candidate.identity, candidate.token)
+	}
+	if !matched {
+		return "", &authFailure{status: http.StatusUnauthorized, reason: "The bearer token is invalid."}
+	}
+	return identity, nil
+}
+
+func clientCertificateIdentities(r *http.Request) ([]string, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	certificate := r.TLS.VerifiedChains[0][0]
+	var identities []string
+	if certificate.Subject.CommonName != "" {
+		identities = append(identities, certificate.Subject.CommonName)
+	}
+	identities = append(identities, certificate.DNSNames...)
+	for _, uri := range certificate.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities, true
+}
+
+type bearerToken struct {
+	identity string
+	token    string
+}
+
+// tokenFile caches the tokens of a file until it is modified, like the [exthttp.CertReloader] does for certificates.
+type tokenFile struct {
+	path string
+
+	mu     sync.Mutex
+	tokens []bearerToken
+	// stat of the loaded file, to reload it once it has been replaced or modified
+	stat os.FileInfo
+}
+
+func (f *tokenFile) load() ([]bearerToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stat, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	// Rotations don't necessarily advance the modification time, e.g. when copying files with their times preserved.
+	if f.tokens != nil && os.SameFile(stat, f.stat) && stat.ModTime().Equal(f.stat.ModTime()) && stat.Size() == f.stat.Size() {
+		return f.tokens, nil
+	}
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	tokens := make([]bearerToken, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// split at the first colon, as tokens may contain colons but identities don't
+		identity, token, found := strings.Cut(line, ":")
+		if !found {
+			identity, token = "", line
+		}
+		tokens = append(tokens, bearerToken{identity: strings.TrimSpace(identity), token: strings.TrimSpace(token)})
+	}
+	f.tokens = tokens
+	f.stat = stat
+	log.Debug().Str("path", f.path).Int("tokens", len(tokens)).Msg("loaded bearer tokens")
+	return tokens, nil
+}
+
+func writeProblem(w http.ResponseWriter, failure authFailure) {
+	if failure.status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="preflight-kit"`)
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(failure.status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+	}{
+		Type:   "about:blank",
+		Title:  http.StatusText(failure.status),
+		Status: failure.status,
+		Detail: failure.reason,
+	})
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useAuthentication(t *testing.T, auth Authentication) {
+	require.NoError(t, SetAuthentication(auth))
+	t.Cleanup(func() { authentication.Store(nil) })
+}
+
+func authenticatedRequest(token string, commonName string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/ExamplePreflightId/start", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	if commonName != "" {
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: commonName}}}}}
+	}
+	return r
+}
+
+func serveAuthenticated(r *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	authenticated(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})).ServeHTTP(recorder, r)
+	return recorder
+}
+
+func Test_authentication_is_disabled_by_default(t *testing.T) {
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("", "")).Code)
+}
+
+func Test_authentication_with_bearer_tokens(t *testing.T) {
+	useAuthentication(t, Authentication{Tokens: map[string]string{"agent-1": "secret"}, AllowedIdentities: []string{"agent-1"}})
+
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("secret", "")).Code)
+
+	recorder := serveAuthenticated(authenticatedRequest("", ""))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Equal(t, "application/problem+json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `Bearer realm="preflight-kit"`, recorder.Header().Get("WWW-Authenticate"))
+	var problem map[string]any
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, "Unauthorized", problem["title"])
+	assert.Equal(t, float64(http.StatusUnauthorized), problem["status"])
+	assert.Equal(t, "A bearer token is required.", problem["detail"])
+
+	assert.Equal(t, http.StatusUnauthorized, serveAuthenticated(authenticatedRequest("wrong", "")).Code)
+}
+
+func Test_authentication_with_token_file_containing_colons(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(path, []byte("agent-1:with:colons\n:anonymous:colons\n"), 0o600))
+	useAuthentication(t, Authentication{TokenFile: path})
+
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("with:colons", "")).Code)
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("anonymous:colons", "")).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAuthenticated(authenticatedRequest("colons", "")).Code)
+}
+
+func Test_authentication_with_rotated_token_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(path, []byte("# agent tokens\nagent-1:first\n"), 0o600))
+	useAuthentication(t, Authentication{TokenFile: path})
+
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("first", "")).Code)
+
+	require.NoError(t, os.WriteFile(path, []byte("agent-1:second\n"), 0o600))
+	modified := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modified, modified))
+
+	assert.Equal(t, http.StatusUnauthorized, serveAuthenticated(authenticatedRequest("first", "")).Code)
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("second", "")).Code)
+}
+
+func Test_authentication_with_token_file_rotated_with_identical_modification_time(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(path, []byte("agent-1:first\n"), 0o600))
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	useAuthentication(t, Authentication{TokenFile: path})
+
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("first", "")).Code)
+
+	require.NoError(t, os.WriteFile(path, []byte("agent-1:rotated\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, stat.ModTime(), stat.ModTime()))
+
+	assert.Equal(t, http.StatusUnauthorized, serveAuthenticated(authenticatedRequest("first", "")).Code)
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("rotated", "")).Code)
+
+	// replaced by a file of the same size, like a renamed secret
+	replacement := filepath.Join(filepath.Dir(path), "tokens.new")
+	require.NoError(t, os.WriteFile(replacement, []byte("agent-1:renamed\n"), 0o600))
+	require.NoError(t, os.Chtimes(replacement, stat.ModTime(), stat.ModTime()))
+	require.NoError(t, os.Rename(replacement, path))
+
+	assert.Equal(t, http.StatusUnauthorized, serveAuthenticated(authenticatedRequest("rotated", "")).Code)
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("renamed", "")).Code)
+}
+
+func Test_authentication_with_missing_token_file(t *testing.T) {
+	err := SetAuthentication(Authentication{TokenFile: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to read token file")
+	assert.Nil(t, authentication.Load())
+}
+
+func Test_authentication_with_client_certificates(t *testing.T) {
+	useAuthentication(t, Authentication{RequireClientCertificate: true, AllowedIdentities: []string{"agent-1"}})
+
+	assert.Equal(t, http.StatusNoContent, serveAuthenticated(authenticatedRequest("", "agent-1")).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAuthenticated(authenticatedRequest("", "")).Code)
+
+	recorder := serveAuthenticated(authenticatedRequest("", "agent-2"))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("WWW-Authenticate"))
+}
+
+func Test_RequireAuthentication(t *testing.T) {
+	useAuthentication(t, Authentication{Tokens: map[string]string{"agent-1": "secret"}})
+	handler := RequireAuthentication(func(w http.ResponseWriter, _ *http.Request, _ []byte) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, authenticatedRequest("secret", ""), nil)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, authenticatedRequest("", ""), nil)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	callbackDelivered = "delivered"
+	callbackFailed    = "failed"
+)
+
+// ErrNoCallback is returned by [CompletePreflight] if the agent hasn't passed a callback for the execution, e.g.
+// because it doesn't support callbacks or the extension has been restarted since. The agent gets the result by
+// polling the status endpoint instead.
+var ErrNoCallback = errors.New("no callback for the execution")
+
+var (
+	callbacks      = sync.Map{}
+	callbackClient = &http.Client{Timeout: 10 * time.Second}
+	// callbackBackoff are the delays between the attempts to deliver a callback.
+	callbackBackoff = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+)
+
+type callback struct {
+	preflightId string
+	url         string
+	secret      []byte
+	// end releases what the execution holds, as the agent stops polling once it got the callback.
+	end func(ctx context.Context)
+}
+
+// CompletePreflight pushes the final status result of an execution to the callback passed by the agent in the start
+// request. Preflights advertising SupportsCallback in their description call it once their background job has
+// completed, instead of letting the agent wait for the next status call. Status must still report the result, as
+// polling remains the fallback.
+//
+// The result is marked as completed and published to the status streams of the execution (see [WithStatusStream]).
+// Like a completed status, it ends the execution, e.g. its slot (see [WithLimits]) is released. The result is POSTed
+// as [preflight_kit_api.StatusCallbackRequestBody], signed with the callback's secret. Deliveries failing with a network
+// error, 429 or 5xx are retried up to four times with a backoff of 1s, 2s, 4s and 8s, or until ctx is done; other
+// failures aren't retried. Returns [ErrNoCallback], if there is no callback for the execution.
+func CompletePreflight(ctx context.Context, preflightActionExecutionId uuid.UUID, result preflight_kit_api.StatusResult) error {
+	result.Completed = true
+	publishStatus(preflightActionExecutionId, result)
+
+	value, ok := callbacks.LoadAndDelete(preflightActionExecutionId)
+	if !ok {
+		return ErrNoCallback
+	}
+	c := value.(callback)
+	defer c.end(ctx)
+
+	ctx, span := tracer().Start(ctx, "preflight.callback",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attributePreflightId.String(c.preflightId),
+			attributeExecutionId.String(preflightActionExecutionId.String()),
+		),
+	)
+	defer span.End()
+
+	body, err := json.Marshal(preflight_kit_api.StatusCallbackRequestBody{
+		PreflightActionExecutionId: preflightActionExecutionId,
+		Result:                     result,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to encode callback")
+		return fmt.Errorf("failed to encode callback: %w", err)
+	}
+
+	err = deliverCallback(ctx, c, body)
+	if err != nil {
+		callbacksTotal.WithLabelValues(c.preflightId, callbackFailed).Inc()
+		span.SetStatus(codes.Error, err.Error())
+		log.Warn().
+			Err(err).
+			Str("preflightActionId", c.preflightId).
+			Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+			Msg("Failed to deliver callback, the agent will poll the status instead.")
+		return err
+	}
+	callbacksTotal.WithLabelValues(c.preflightId, callbackDelivered).Inc()
+	return nil
+}
+
+func deliverCallback(ctx context.Context, c callback, body []byte) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		if retryable, err = postCallback(ctx, c, body); err == nil || !retryable || attempt == len(callbackBackoff) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-currentClock().After(callbackBackoff[attempt]):
+		}
+	}
+}
+
+// postCallback sends the callback once and returns whether a failure is worth retrying.
+func postCallback(ctx context.Context, c callback, body []byte) (retryable bool, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(currentClock().Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(preflight_kit_api.CallbackTimestampHeader, timestamp)
+	request.Header.Set(preflight_kit_api.CallbackSignatureHeader, signCallback(c.secret, timestamp, body))
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := callbackClient.Do(request)
+	if err != nil {
+		return true, err
+	}
+	_ = response.Body.Close()
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	retryable = response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("callback answered with %s", response.Status)
+}
+
+// signCallback returns the signature of a callback: the HMAC-SHA256 of the timestamp, a dot and the body.
+func signCallback(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// validateCallback checks that the callback of a start request can be called.
+func validateCallback(c *preflight_kit_api.PreflightCallback) error {
+	parsed, err := url.Parse(c.Url)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute http(s) URL", c.Url)
+	}
+	if c.Secret == "" {
+		return errors.New("secret is missing")
+	}
+	return nil
+}
+
+// rememberCallback keeps the callback of a started execution, if the preflight supports callbacks.
+func (a *preflightHttpAdapter[T]) rememberCallback(parsedBody preflight_kit_api.StartPreflightRequestBody) {
+	if !a.supportsCallback() || parsedBody.Callback == nil {
+		return
+	}
+	callbacks.Store(parsedBody.PreflightActionExecutionId, callback{
+		preflightId: a.description.Id,
+		url:         parsedBody.Callback.Url,
+		secret:      []byte(parsedBody.Callback.Secret),
+		end: func(ctx context.Context) {
+			a.endExecution(context.WithoutCancel(ctx), parsedBody.PreflightActionExecutionId)
+		},
+	})
+}
+
+func (a *preflightHttpAdapter[T]) supportsCallback() bool {
+	return a.description.SupportsCallback != nil && *a.description.SupportsCallback
+}
+
+func forgetCallback(preflightActionExecutionId uuid.UUID) {
+	callbacks.Delete(preflightActionExecutionId)
+}
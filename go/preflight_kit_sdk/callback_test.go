@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type callbackReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+// newCallbackReceiver answers the callbacks with the statuses in turn, and with 204 once they are used up.
+func newCallbackReceiver(t *testing.T, statuses ...int) *callbackReceiver {
+	receiver := &callbackReceiver{statuses: statuses}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		receiver.requests = append(receiver.requests, r)
+		receiver.bodies = append(receiver.bodies, body)
+		status := http.StatusNoContent
+		if len(receiver.statuses) > 0 {
+			status, receiver.statuses = receiver.statuses[0], receiver.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *callbackReceiver) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func useFastCallbackBackoff(t *testing.T) {
+	backoff := callbackBackoff
+	callbackBackoff = []time.Duration{time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { callbackBackoff = backoff })
+}
+
+func newCallbackAdapter(supportsCallback bool, opts ...PreflightOption) *preflightHttpAdapter[ExampleState] {
+	preflight := withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.SupportsCallback = extutil.Ptr(supportsCallback)
+	})
+	preflight.calls = make(chan Call, 10)
+	return newPreflightHttpAdapter[ExampleState](preflight, opts...)
+}
+
+func startWithCallback(t *testing.T, adapter *preflightHttpAdapter[ExampleState], executionId uuid.UUID, callback preflight_kit_api.PreflightCallback) *httptest.ResponseRecorder {
+	body, err := json.Marshal(preflight_kit_api.StartPreflightRequestBody{PreflightActionExecutionId: executionId, Callback: &callback})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleStart(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Start.Path, bytes.NewReader(body)))
+	if recorder.Code == http.StatusOK {
+		t.Cleanup(func() { cancelExecution(t, adapter, executionId, preflight_kit_api.PreflightState{}) })
+	}
+	return recorder
+}
+
+func Test_CompletePreflight_pushes_signed_result(t *testing.T) {
+	receiver := newCallbackReceiver(t)
+	adapter := newCallbackAdapter(true)
+	executionId := uuid.New()
+	require.Equal(t, http.StatusOK, startWithCallback(t, adapter, executionId, preflight_kit_api.PreflightCallback{Url: receiver.URL + "/callback", Secret: "secret"}).Code)
+
+	err := CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{
+		Summary: &preflight_kit_api.Summary{Level: preflight_kit_api.SummaryLevelInfo, Text: "approved"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, receiver.received())
+	request, body := receiver.requests[0], receiver.bodies[0]
+	assert.Equal(t, "/callback", request.URL.Path)
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	timestamp := request.Header.Get(preflight_kit_api.CallbackTimestampHeader)
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, signCallback([]byte("secret"), timestamp, body), request.Header.Get(preflight_kit_api.CallbackSignatureHeader))
+
+	var callbackBody preflight_kit_api.StatusCallbackRequestBody
+	require.NoError(t, json.Unmarshal(body, &callbackBody))
+	assert.Equal(t, executionId, callbackBody.PreflightActionExecutionId)
+	assert.True(t, callbackBody.Result.Completed, "callbacks carry the final result")
+	assert.Equal(t, "approved", callbackBody.Result.Summary.Text)
+
+	assert.ErrorIs(t, CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{}), ErrNoCallback, "a callback is only delivered once")
+}
+
+func Test_CompletePreflight_releases_the_slot(t *testing.T) {
+	receiver := newCallbackReceiver(t)
+	adapter := newCallbackAdapter(true, WithLimits(Limits{MaxActiveExecutions: 1}))
+	first, second := uuid.New(), uuid.New()
+	require.Equal(t, http.StatusOK, startWithCallback(t, adapter, first, preflight_kit_api.PreflightCallback{Url: receiver.URL, Secret: "secret"}).Code)
+	require.NotNil(t, startExecution(t, adapter, second).Error, "the slot is taken")
+
+	require.NoError(t, CompletePreflight(context.Background(), first, preflight_kit_api.StatusResult{}))
+
+	result := startExecution(t, adapter, second)
+	assert.Nil(t, result.Error, "the agent stops polling once it got the callback, so the slot is released right away")
+	cancelExecution(t, adapter, second, result.State)
+}
+
+func Test_signCallback(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", signCallback([]byte("secret"), "1700000000", []byte("{}")))
+}
+
+func Test_CompletePreflight_retries_failed_deliveries(t *testing.T) {
+	useFastCallbackBackoff(t)
+	receiver := newCallbackReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	adapter := newCallbackAdapter(true)
+	executionId := uuid.New()
+	require.Equal(t, http.StatusOK, startWithCallback(t, adapter, executionId, preflight_kit_api.PreflightCallback{Url: receiver.URL, Secret: "secret"}).Code)
+
+	require.NoError(t, CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{}))
+	assert.Equal(t, 3, receiver.received())
+}
+
+func Test_CompletePreflight_gives_up(t *testing.T) {
+	useFastCallbackBackoff(t)
+
+	t.Run("after the last attempt", func(t *testing.T) {
+		receiver := newCallbackReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+		adapter := newCallbackAdapter(true)
+		executionId := uuid.New()
+		require.Equal(t, http.StatusOK, startWithCallback(t, adapter, executionId, preflight_kit_api.PreflightCallback{Url: receiver.URL, Secret: "secret"}).Code)
+
+		assert.ErrorContains(t, CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{}), "502 Bad Gateway")
+		assert.Equal(t, 1+len(callbackBackoff), receiver.received())
+	})
+
+	t.Run("on client errors", func(t *testing.T) {
+		receiver := newCallbackReceiver(t, http.StatusUnauthorized)
+		adapter := newCallbackAdapter(true)
+		executionId := uuid.New()
+		require.Equal(t, http.StatusOK, startWithCallback(t, adapter, executionId, preflight_kit_api.PreflightCallback{Url: receiver.URL, Secret: "secret"}).Code)
+
+		assert.ErrorContains(t, CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{}), "401 Unauthorized")
+		assert.Equal(t, 1, receiver.received())
+	})
+}
+
+func Test_callbacks_require_support(t *testing.T) {
+	receiver := newCallbackReceiver(t)
+	adapter := newCallbackAdapter(false)
+	executionId := uuid.New()
+	require.Equal(t, http.StatusOK, startWithCallback(t, adapter, executionId, preflight_kit_api.PreflightCallback{Url: receiver.URL, Secret: "secret"}).Code)
+
+	assert.ErrorIs(t, CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{}), ErrNoCallback)
+	assert.Zero(t, receiver.received())
+}
+
+func Test_handleStart_rejects_invalid_callbacks(t *testing.T) {
+	adapter := newCallbackAdapter(true)
+	for _, callback := range []preflight_kit_api.PreflightCallback{
+		{Url: "/relative", Secret: "secret"},
+		{Url: "ftp://agent.example.com/callback", Secret: "secret"},
+		{Url: "https://agent.example.com/callback"},
+	} {
+		assert.Equal(t, http.StatusBadRequest, startWithCallback(t, adapter, uuid.New(), callback).Code, callback.Url)
+	}
+}
+
+func Test_cancel_forgets_callback(t *testing.T) {
+	receiver := newCallbackReceiver(t)
+	adapter := newCallbackAdapter(true)
+	executionId := uuid.New()
+	require.Equal(t, http.StatusOK, startWithCallback(t, adapter, executionId, preflight_kit_api.PreflightCallback{Url: receiver.URL, Secret: "secret"}).Code)
+
+	cancelExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.ErrorIs(t, CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{}), ErrNoCallback)
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"sync/atomic"
+
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/clock"
+)
+
+var sdkClock atomic.Pointer[clock.Clock]
+
+// SetClock replaces the clock used for heartbeats, deadlines and stop events. It is meant for tests, which pass a
+// clocktest.FakeClock to control the passing of time. Timers created before the call keep using the previous clock.
+func SetClock(c clock.Clock) {
+	sdkClock.Store(&c)
+}
+
+func currentClock() clock.Clock {
+	if c := sdkClock.Load(); c != nil {
+		return *c
+	}
+	return clock.Real()
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+// Package clock abstracts the passing of time, so that the timing behavior of the SDK (heartbeats, deadlines, stop
+// events) can be tested deterministically. Use [Real] in production and clocktest.FakeClock in tests.
+package clock
+
+import (
+	"time"
+)
+
+// Clock tells the current time and creates timers.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a timer, which sends the current time on its channel after at least the duration has elapsed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the equivalent of [time.Timer] for a [Clock].
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false, if the timer has already fired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after the duration. It returns true, if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Real returns a clock backed by the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t *realTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package clocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock_fires_timers_when_advanced(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	timer := c.NewTimer(time.Second)
+
+	c.Advance(999 * time.Millisecond)
+	assert.Empty(t, timer.C())
+	assert.Equal(t, 1, c.Timers())
+
+	c.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-timer.C())
+	assert.Equal(t, 0, c.Timers())
+	assert.False(t, timer.Stop())
+}
+
+func TestFakeClock_stopped_timers_do_not_fire(t *testing.T) {
+	c := NewFakeClock(time.Now())
+	timer := c.NewTimer(time.Second)
+
+	assert.True(t, timer.Stop())
+	c.Advance(time.Minute)
+	assert.Empty(t, timer.C())
+
+	assert.False(t, timer.Reset(time.Second))
+	c.Advance(time.Second)
+	assert.Len(t, timer.C(), 1)
+}
+
+func TestFakeClock_BlockUntil_waits_for_timers(t *testing.T) {
+	c := NewFakeClock(time.Now())
+	go c.After(time.Second)
+	c.BlockUntil(1)
+	assert.Equal(t, time.Duration(0), c.Since(c.Now()))
+}
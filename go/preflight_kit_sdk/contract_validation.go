@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// ContractValidationMode decides whether the SDK validates the bodies of incoming requests and outgoing results against
+// the OpenAPI spec of the preflight kit API. Validation costs an extra encoding round trip per call and is meant for
+// CI and staging environments.
+type ContractValidationMode string
+
+const (
+	// ContractValidationOff disables the validation. This is the default.
+	ContractValidationOff ContractValidationMode = "off"
+	// ContractValidationLog logs violations, but serves requests and results unchanged.
+	ContractValidationLog ContractValidationMode = "log"
+	// ContractValidationReject answers invalid requests with 400 and replaces invalid results with a 500, both with a
+	// [preflight_kit_api.PreflightKitError] listing the violations.
+	ContractValidationReject ContractValidationMode = "reject"
+)
+
+// WithContractValidation validates the requests and results of the preflight against the preflight kit API spec.
+func WithContractValidation(mode ContractValidationMode) PreflightOption {
+	return func(o *preflightOptions) {
+		o.contractValidation = mode
+	}
+}
+
+var loadContractSpec = sync.OnceValues(preflight_kit_api.GetSwagger)
+
+// contractViolation is a single deviation from the spec. Path is the JSON pointer of the offending value.
+type contractViolation struct {
+	Path   string
+	Reason string
+}
+
+func (v contractViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Reason)
+}
+
+type contractValidator struct {
+	mode        ContractValidationMode
+	preflightId string
+	spec        *openapi3.T
+}
+
+// newContractValidator returns nil if the validation is disabled or the spec can't be loaded.
+func newContractValidator(mode ContractValidationMode, preflightId string) *contractValidator {
+	if mode == "" || mode == ContractValidationOff {
+		return nil
+	}
+	spec, err := loadContractSpec()
+	if err != nil {
+		log.Error().Err(err).Str("preflightActionId", preflightId).Msg("Failed to load the preflight kit API spec, contract validation is disabled.")
+		return nil
+	}
+	return &contractValidator{mode: mode, preflightId: preflightId, spec: spec}
+}
+
+// wrap validates the request body against the named request body of the spec and the result against the named schema.
+// Error responses are validated against the PreflightKitError schema. An empty requestBody skips the request
+// validation.
+func (c *contractValidator) wrap(requestBody, result string, handler http.HandlerFunc) http.HandlerFunc {
+	if c == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requestBody != "" {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				// leave the failure to the handler reading the body, e.g. to answer a too large body with 413
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err}))
+				handler(w, r)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			if violations := c.validate(c.requestBodySchema(requestBody), body); len(violations) > 0 {
+				c.report("request", r.URL.Path, violations)
+				if c.mode == ContractValidationReject {
+					writeContractViolations(w, http.StatusBadRequest, "Request violates the preflight kit API.", violations)
+					return
+				}
+			}
+		}
+
+		recorder := &bufferedResponseWriter{header: make(http.Header), status: http.StatusOK}
+		handler(recorder, r)
+		if recorder.status == http.StatusNotModified || recorder.body.Len() == 0 {
+			// nothing to validate, e.g. the answer to a conditional request
+			recorder.writeTo(w)
+			return
+		}
+
+		schemaName := result
+		if recorder.status >= http.StatusBadRequest {
+			schemaName = "PreflightKitError"
+		}
+		if violations := c.validate(c.schema(schemaName), recorder.body.Bytes()); len(violations) > 0 {
+			c.report("response", r.URL.Path, violations)
+			if c.mode == ContractValidationReject {
+				writeContractViolations(w, http.StatusInternalServerError, "Preflight result violates the preflight kit API.", violations)
+				return
+			}
+		}
+		recorder.writeTo(w)
+	}
+}
+
+func (c *contractValidator) schema(name string) *openapi3.Schema {
+	if ref, ok := c.spec.Components.Schemas[name]; ok && ref != nil {
+		return ref.Value
+	}
+	return nil
+}
+
+func (c *contractValidator) requestBodySchema(name string) *openapi3.Schema {
+	ref, ok := c.spec.Components.RequestBodies[name]
+	if !ok || ref == nil || ref.Value == nil {
+		return nil
+	}
+	mediaType := ref.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil {
+		return nil
+	}
+	return mediaType.Schema.Value
+}
+
+func (c *contractValidator) validate(schema *openapi3.Schema, body []byte) []contractViolation {
+	if schema == nil {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []contractViolation{{Path: "/", Reason: fmt.Sprintf("invalid JSON: %s", err.Error())}}
+	}
+	err := schema.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+	return toContractViolations(err)
+}
+
+func (c *contractValidator) report(direction, path string, violations []contractViolation) {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	log.Warn().
+		Str("preflightActionId", c.preflightId).
+		Str("path", path).
+		Strs("violations", messages).
+		Msgf("Preflight %s violates the preflight kit API.", direction)
+}
+
+func toContractViolations(err error) []contractViolation {
+	var multiError openapi3.MultiError
+	if errors.As(err, &multiError) {
+		violations := make([]contractViolation, 0, len(multiError))
+		for _, e := range multiError {
+			violations = append(violations, toContractViolations(e)...)
+		}
+		return violations
+	}
+	var schemaError *openapi3.SchemaError
+	if errors.As(err, &schemaError) {
+		reason := schemaError.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("doesn't match schema %q", schemaError.SchemaField)
+		}
+		return []contractViolation{{Path: "/" + strings.Join(schemaError.JSONPointer(), "/"), Reason: reason}}
+	}
+	return []contractViolation{{Path: "/", Reason: err.Error()}}
+}
+
+func writeContractViolations(w http.ResponseWriter, status int, title string, violations []contractViolation) {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(preflight_kit_api.PreflightKitError{
+		Title:  title,
+		Detail: extutil.Ptr(strings.Join(messages, "; ")),
+		Status: extutil.Ptr(preflight_kit_api.Errored),
+	})
+}
+
+type errorReader struct {
+	err error
+}
+
+func (e errorReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
+
+// bufferedResponseWriter holds back a response until it has been validated.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponseWriter) writeTo(w http.ResponseWriter) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(b.status)
+	_, _ = w.Write(b.body.Bytes())
+}
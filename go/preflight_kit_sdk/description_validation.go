@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// callIntervalPattern is the pattern of the preflight kit API spec for call intervals.
+var callIntervalPattern = regexp.MustCompile(`^(\d+)(ns|ms|s|m|h|d)$`)
+
+// ValidatePreflight validates the description of a preflight like [RegisterPreflight] does, except for conflicts with
+// other registered preflights. Use it in the unit tests of your preflights.
+func ValidatePreflight[T any](preflight Preflight[T], opts ...PreflightOption) error {
+	return newPreflightHttpAdapter(preflight, opts...).validate()
+}
+
+// validate returns all problems of the description, joined into one error.
+func (a *preflightHttpAdapter[T]) validate() error {
+	description := a.description
+	var problems []error
+	problem := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if description.Id == "" {
+		problem("id is missing")
+	} else if strings.ContainsAny(description.Id, "/?#{} \t\n") {
+		problem("id %q can't be used in a path", description.Id)
+	}
+	if description.Label == "" {
+		problem("label is missing")
+	}
+	if description.Version == "" {
+		problem("version is missing")
+	}
+	if description.Icon != nil {
+		if err := validateIcon(*description.Icon); err != nil {
+			problem("icon is invalid: %w", err)
+		}
+	}
+	if _, err := parseCallInterval(*description.Status.CallInterval); err != nil {
+		problem("status call interval is invalid: %w", err)
+	}
+
+	seen := make(map[string]string)
+	for _, r := range a.routes() {
+		if !strings.HasPrefix(r.path, "/") {
+			problem("%s path %q must start with /", r.name, r.path)
+		}
+		// The description declares the paths and methods of start, status and cancel, the SDK those of the other routes.
+		if r.name == callStart || r.name == callStatus || r.name == callCancel {
+			if !isMutatingMethod(r.method) {
+				problem("%s method %q must be one of POST, PUT or DELETE", r.name, r.method)
+			}
+			if strings.ContainsAny(r.path, "{}") {
+				problem("%s path %q must not contain wildcards", r.name, r.path)
+			} else if len(r.path) > 1 && strings.HasSuffix(r.path, "/") {
+				problem("%s path %q must not end with /", r.name, r.path)
+			}
+		}
+		pattern := r.method + " " + r.path
+		if err := validatePattern(pattern); err != nil {
+			problem("%s can't be served: %w", r.name, err)
+			continue
+		}
+		if other, exists := seen[pattern]; exists {
+			problem("%s and %s both use %s", other, r.name, pattern)
+		}
+		seen[pattern] = r.name
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid description of preflight %q: %w", description.Id, errors.Join(problems...))
+	}
+	return nil
+}
+
+// validateRegistration checks that the preflight doesn't conflict with the registered preflights. Preflights can't share
+// a path, even with different methods, as each path answers other methods with 405 and the methods of one preflight.
+func (a *preflightHttpAdapter[T]) validateRegistration() error {
+	if _, exists := registeredPreflights[a.description.Id]; exists {
+		return fmt.Errorf("preflight %q is already registered", a.description.Id)
+	}
+	var problems []error
+	for _, r := range a.routes() {
+		if other, exists := registeredPaths[r.path]; exists {
+			problems = append(problems, fmt.Errorf("%s of preflight %q conflicts with preflight %q on %s", r.name, a.description.Id, other, r.path))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// validatePattern parses the pattern like [http.ServeMux.Handle] does, which panics on invalid patterns.
+func validatePattern(pattern string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	http.NewServeMux().Handle(pattern, http.NotFoundHandler())
+	return nil
+}
+
+func isMutatingMethod(method string) bool {
+	switch preflight_kit_api.MutatingHttpMethod(method) {
+	case preflight_kit_api.POST, preflight_kit_api.PUT, preflight_kit_api.DELETE:
+		return true
+	}
+	return false
+}
+
+// parseCallInterval parses a call interval in the format of the preflight kit API spec, which also allows days.
+func parseCallInterval(callInterval string) (time.Duration, error) {
+	match := callIntervalPattern.FindStringSubmatch(callInterval)
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a duration like 100ms or 10s", callInterval)
+	}
+	var interval time.Duration
+	if match[2] == "d" {
+		days, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, err
+		}
+		interval = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		if interval, err = time.ParseDuration(callInterval); err != nil {
+			return 0, err
+		}
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("%q must be positive", callInterval)
+	}
+	return interval, nil
+}
+
+// validateIcon checks that the icon is a data URI of an image.
+func validateIcon(icon string) error {
+	if !strings.HasPrefix(icon, "data:") {
+		return errors.New("must be a data URI")
+	}
+	header, data, found := strings.Cut(strings.TrimPrefix(icon, "data:"), ",")
+	if !found {
+		return errors.New("data URI has no data")
+	}
+	mediaType, _, _ := strings.Cut(header, ";")
+	if !strings.HasPrefix(mediaType, "image/") {
+		return fmt.Errorf("media type %q is not an image", mediaType)
+	}
+	if strings.HasSuffix(header, ";base64") {
+		if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+			return fmt.Errorf("data isn't base64 encoded: %w", err)
+		}
+	}
+	return nil
+}
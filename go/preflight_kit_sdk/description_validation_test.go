@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describedPreflight struct {
+	*ExamplePreflight
+	description preflight_kit_api.PreflightDescription
+}
+
+func (p *describedPreflight) Describe() preflight_kit_api.PreflightDescription {
+	return p.description
+}
+
+func withDescription(modify func(description *preflight_kit_api.PreflightDescription)) *describedPreflight {
+	example := NewExamplePreflight(nil)
+	description := example.Describe()
+	modify(&description)
+	return &describedPreflight{example, description}
+}
+
+func Test_ValidatePreflight(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(description *preflight_kit_api.PreflightDescription)
+		problems []string
+	}{
+		{
+			name:   "valid",
+			modify: func(description *preflight_kit_api.PreflightDescription) {},
+		},
+		{
+			name: "valid with icon and call interval in days",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Icon = new("data:image/svg+xml;base64,PHN2Zy8+")
+				description.Status.CallInterval = new("1d")
+			},
+		},
+		{
+			name: "missing id, label and version",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Id = ""
+				description.Label = ""
+				description.Version = ""
+			},
+			problems: []string{"id is missing", "label is missing", "version is missing"},
+		},
+		{
+			name: "id with slash",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Id = "com/example"
+			},
+			problems: []string{`id "com/example" can't be used in a path`},
+		},
+		{
+			name: "id with wildcard",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Id = "probe{x}"
+			},
+			problems: []string{`id "probe{x}" can't be used in a path`},
+		},
+		{
+			name: "path with wildcard",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Status.Path = "/probe-custom/{executionId}/status"
+			},
+			problems: []string{`status path "/probe-custom/{executionId}/status" must not contain wildcards`},
+		},
+		{
+			name: "path with trailing slash",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Start.Path = "/probe-custom/start/"
+			},
+			problems: []string{`start path "/probe-custom/start/" must not end with /`},
+		},
+		{
+			name: "path that can't be served",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Start.Path = "/probe-custom/../start"
+			},
+			problems: []string{`start can't be served: parsing "POST /probe-custom/../start": at offset 5: non-CONNECT pattern with unclean path can never match`},
+		},
+		{
+			name: "read only method",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Start.Method = "GET"
+			},
+			problems: []string{`start method "GET" must be one of POST, PUT or DELETE`},
+		},
+		{
+			name: "unparsable call interval",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Status.CallInterval = new("1 second")
+			},
+			problems: []string{`status call interval is invalid: "1 second" is not a duration like 100ms or 10s`},
+		},
+		{
+			name: "zero call interval",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Status.CallInterval = new("0s")
+			},
+			problems: []string{`status call interval is invalid: "0s" must be positive`},
+		},
+		{
+			name: "invalid icons",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Icon = new("data:text/plain,hello")
+			},
+			problems: []string{`icon is invalid: media type "text/plain" is not an image`},
+		},
+		{
+			name: "colliding paths",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Start.Path = "/example"
+				description.Status.Path = "/example"
+			},
+			problems: []string{"start and status both use POST /example"},
+		},
+		{
+			name: "relative path and invalid method",
+			modify: func(description *preflight_kit_api.PreflightDescription) {
+				description.Cancel.Path = "cancel"
+				description.Cancel.Method = "PATCH"
+			},
+			problems: []string{`cancel path "cancel" must start with /`, `cancel method "PATCH" must be one of POST, PUT or DELETE`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePreflight[ExampleState](withDescription(tt.modify))
+			if len(tt.problems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, problem := range tt.problems {
+				assert.ErrorContains(t, err, problem)
+			}
+		})
+	}
+}
+
+func Test_RegisterPreflight_rejects_conflicts(t *testing.T) {
+	ClearRegisteredPreflights()
+	t.Cleanup(ClearRegisteredPreflights)
+
+	err := RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.Label = ""
+	}))
+	assert.ErrorContains(t, err, "label is missing")
+	assert.Empty(t, registeredPreflights, "invalid preflights aren't registered")
+
+	require.NoError(t, RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.Id = "ConflictsPreflightId"
+	})))
+	err = RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.Id = "ConflictsPreflightId"
+	}))
+	assert.EqualError(t, err, `preflight "ConflictsPreflightId" is already registered`)
+
+	err = RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.Id = "OtherPreflightId"
+		description.Start.Path = "/ConflictsPreflightId/start"
+	}))
+	assert.EqualError(t, err, `start of preflight "OtherPreflightId" conflicts with preflight "ConflictsPreflightId" on /ConflictsPreflightId/start`)
+
+	err = RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.Id = "OtherPreflightId"
+		description.Start.Method = preflight_kit_api.PUT
+		description.Start.Path = "/ConflictsPreflightId/start"
+	}))
+	assert.EqualError(t, err, `start of preflight "OtherPreflightId" conflicts with preflight "ConflictsPreflightId" on /ConflictsPreflightId/start`, "paths are shared by all methods")
+
+	err = RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+		description.Id = "OtherPreflightId"
+		description.Status.Path = "/ConflictsPreflightId"
+	}))
+	assert.EqualError(t, err, `status of preflight "OtherPreflightId" conflicts with preflight "ConflictsPreflightId" on /ConflictsPreflightId`, "the root path of a preflight is one of its paths")
+
+	assert.Panics(t, func() {
+		MustRegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+			description.Id = ""
+		}))
+	})
+}
+
+func Test_parseCallInterval(t *testing.T) {
+	interval, err := parseCallInterval("2d")
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, interval)
+
+	interval, err = parseCallInterval("250ms")
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, interval)
+
+	_, err = parseCallInterval("1.5s")
+	assert.Error(t, err)
+}
@@ -49,6 +49,8 @@ func (preflight *ExamplePreflight) NewEmptyState() ExampleState {
 func (preflight *ExamplePreflight) Describe() preflight_kit_api.PreflightDescription {
 	return preflight_kit_api.PreflightDescription{
 		Id:                      "ExamplePreflightId",
+		Label:                   "Example Preflight",
+		Version:                 "1.0.0",
 		Description:             "This is an Example Preflight",
 		Start:                   preflight_kit_api.MutatingEndpointReference{},
 		TargetAttributeIncludes: []string{"target.attribute.to.include", "target.attribute.to.include.2"},
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+type loggerKey struct{}
+
+// Logger returns the logger of the execution the context belongs to. The SDK passes contexts with such a logger into
+// Start, Status and Cancel. Its entries carry the preflight id, the execution id and, if known, the experiment key, the
+// experiment execution id and the creator of the execution. Without an execution, the global logger is returned.
+func Logger(ctx context.Context) *zerolog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+		return logger
+	}
+	return &log.Logger
+}
+
+// executionLabels describes the experiment execution a preflight is started for. The labels are persisted with the
+// state, so the logger of later calls carries them as well.
+func executionLabels(request preflight_kit_api.StartPreflightRequestBody) map[string]string {
+	labels := make(map[string]string)
+	execution := request.ExperimentExecution
+	if execution.Key != nil {
+		labels["experimentKey"] = *execution.Key
+	}
+	if execution.Id != nil {
+		labels["experimentExecutionId"] = strconv.Itoa(int(*execution.Id))
+	}
+	if execution.CreatedBy != nil && execution.CreatedBy.Username != "" {
+		labels["createdBy"] = execution.CreatedBy.Username
+	}
+	return labels
+}
+
+// labelsCache holds the labels of the executions, so Status and Cancel calls log with them without reading the persisted
+// state.
+var labelsCache = sync.Map{}
+
+func rememberLabels(preflightActionExecutionId uuid.UUID, labels map[string]string) {
+	labelsCache.Store(preflightActionExecutionId, labels)
+}
+
+// knownLabels returns the labels remembered at the start of the execution. If they are unknown, e.g. after a
+// restart of the extension, they are read once from the persisted state, as long as the state is persisted at all.
+func knownLabels(ctx context.Context, preflightActionExecutionId uuid.UUID, persisted bool) map[string]string {
+	if labels, ok := labelsCache.Load(preflightActionExecutionId); ok {
+		return labels.(map[string]string)
+	}
+	if !persisted {
+		return nil
+	}
+	persistedState, err := statePersister.GetState(ctx, preflightActionExecutionId)
+	if err != nil {
+		return nil
+	}
+	rememberLabels(preflightActionExecutionId, persistedState.Labels)
+	return persistedState.Labels
+}
+
+func forgetLabels(preflightActionExecutionId uuid.UUID) {
+	labelsCache.Delete(preflightActionExecutionId)
+}
+
+// withExecutionLogger attaches the logger of the execution to the context. It is also available via zerolog.Ctx.
+func withExecutionLogger(ctx context.Context, preflightActionId string, preflightActionExecutionId uuid.UUID, labels map[string]string) context.Context {
+	logContext := log.Logger.With().
+		Str("preflightActionId", preflightActionId).
+		Str("preflightActionExecutionId", preflightActionExecutionId.String())
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		logContext = logContext.Str(key, labels[key])
+	}
+	ctx = logContext.Logger().WithContext(ctx)
+	return context.WithValue(ctx, loggerKey{}, zerolog.Ctx(ctx))
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type loggingPreflight struct {
+	*ExamplePreflight
+	logs bytes.Buffer
+}
+
+func (p *loggingPreflight) log(ctx context.Context, method string) {
+	logger := Logger(ctx).Output(&p.logs)
+	logger.Info().Msg(method)
+}
+
+func (p *loggingPreflight) Start(ctx context.Context, _ *ExampleState, _ preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error) {
+	p.log(ctx, "Start")
+	return nil, nil
+}
+
+func (p *loggingPreflight) Status(ctx context.Context, _ *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	p.log(ctx, "Status")
+	return nil, nil
+}
+
+func (p *loggingPreflight) Cancel(ctx context.Context, _ *ExampleState) (*preflight_kit_api.CancelResult, error) {
+	p.log(ctx, "Cancel")
+	return nil, nil
+}
+
+func Test_execution_logger_is_passed_to_the_preflight(t *testing.T) {
+	p := &loggingPreflight{ExamplePreflight: NewExamplePreflight(make(chan Call, 10))}
+	adapter := newPreflightHttpAdapter[ExampleState](p)
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+
+	body, err := json.Marshal(preflight_kit_api.StartPreflightRequestBody{
+		PreflightActionExecutionId: executionId,
+		ExperimentExecution: preflight_kit_api.ExperimentExecutionAO{
+			Id:        new(int32(42)),
+			Key:       new("ADM-9"),
+			CreatedBy: &preflight_kit_api.UserSummaryAO{Username: "admin"},
+		},
+	})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleStart(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Start.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	cancelExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	lines := strings.Split(strings.TrimSpace(p.logs.String()), "\n")
+	require.Len(t, lines, 3)
+	for i, method := range []string{"Start", "Status", "Cancel"} {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &entry))
+		assert.Equal(t, method, entry["message"])
+		assert.Equal(t, "ExamplePreflightId", entry["preflightActionId"], method)
+		assert.Equal(t, executionId.String(), entry["preflightActionExecutionId"], method)
+		assert.Equal(t, "ADM-9", entry["experimentKey"], method)
+		assert.Equal(t, "42", entry["experimentExecutionId"], method)
+		assert.Equal(t, "admin", entry["createdBy"], method)
+	}
+}
+
+func TestLogger_without_execution(t *testing.T) {
+	assert.Same(t, &log.Logger, Logger(context.Background()))
+}
+
+func TestLogger_is_available_via_zerolog(t *testing.T) {
+	ctx := withExecutionLogger(context.Background(), "preflight", uuid.New(), nil)
+	assert.Same(t, Logger(ctx), zerolog.Ctx(ctx))
+}
+
+type countingStatePersister struct {
+	state_persister.StatePersister
+	gets atomic.Int32
+}
+
+func (p *countingStatePersister) GetState(ctx context.Context, executionId uuid.UUID) (*state_persister.PersistedState, error) {
+	p.gets.Add(1)
+	return p.StatePersister.GetState(ctx, executionId)
+}
+
+func Test_execution_labels_are_read_from_the_persister_once(t *testing.T) {
+	p := &loggingPreflight{ExamplePreflight: NewExamplePreflight(make(chan Call, 10))}
+	adapter := newPreflightHttpAdapter[ExampleState](p)
+	executionId := uuid.New()
+	persister := &countingStatePersister{StatePersister: statePersister}
+	previous := statePersister
+	statePersister = persister
+	t.Cleanup(func() {
+		statePersister = previous
+		_ = statePersister.DeleteState(context.Background(), executionId)
+		forgetLabels(executionId)
+	})
+
+	body, err := json.Marshal(preflight_kit_api.StartPreflightRequestBody{
+		PreflightActionExecutionId: executionId,
+		ExperimentExecution:        preflight_kit_api.ExperimentExecutionAO{Key: new("ADM-10")},
+	})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleStart(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Start.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Zero(t, persister.gets.Load())
+
+	// e.g. after a restart, the labels are read from the persisted state once
+	forgetLabels(executionId)
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Equal(t, int32(1), persister.gets.Load())
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Equal(t, int32(1), persister.gets.Load())
+
+	cancelExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	lines := strings.Split(strings.TrimSpace(p.logs.String()), "\n")
+	require.Len(t, lines, 6)
+	for _, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "ADM-10", entry["experimentKey"], entry["message"])
+	}
+	_, cached := labelsCache.Load(executionId)
+	assert.False(t, cached, "labels are forgotten on cancel")
+}
+
+type loggingNoCancelPreflight struct {
+	noCancelPreflight
+	logs bytes.Buffer
+}
+
+func (p *loggingNoCancelPreflight) log(ctx context.Context, method string) {
+	logger := Logger(ctx).Output(&p.logs)
+	logger.Info().Msg(method)
+}
+
+func (p *loggingNoCancelPreflight) Start(ctx context.Context, state *ExampleState, request preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error) {
+	p.log(ctx, "Start")
+	return p.noCancelPreflight.Start(ctx, state, request)
+}
+
+func (p *loggingNoCancelPreflight) Status(ctx context.Context, state *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	p.log(ctx, "Status")
+	return p.noCancelPreflight.Status(ctx, state)
+}
+
+func Test_execution_labels_of_a_preflight_without_cancel(t *testing.T) {
+	p := &loggingNoCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](p)
+	require.False(t, adapter.persistsState())
+	executionId := uuid.New()
+	t.Cleanup(func() { forgetLabels(executionId) })
+
+	body, err := json.Marshal(preflight_kit_api.StartPreflightRequestBody{
+		PreflightActionExecutionId: executionId,
+		ExperimentExecution:        preflight_kit_api.ExperimentExecutionAO{Key: new("ADM-11")},
+	})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleStart(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Start.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	p.completed = true
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	lines := strings.Split(strings.TrimSpace(p.logs.String()), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "ADM-11", entry["experimentKey"], entry["message"])
+	}
+	_, cached := labelsCache.Load(executionId)
+	assert.False(t, cached, "labels are forgotten on completion")
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+var (
+	pendingRecoveries atomic.Int32
+	shuttingDown      atomic.Bool
+)
+
+// HealthStatus is the body of the readiness and liveness responses. Checks maps the name of each check to "ok" or the
+// reason why it failed.
+type HealthStatus struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// BeginStartupRecovery marks a startup recovery as in progress, e.g. resuming the executions of a persistent state
+// persister. The preflights are not ready until the returned function has been called.
+func BeginStartupRecovery() (done func()) {
+	pendingRecoveries.Add(1)
+	var once atomic.Bool
+	return func() {
+		if once.CompareAndSwap(false, true) {
+			pendingRecoveries.Add(-1)
+		}
+	}
+}
+
+// beginShutdown makes the preflights not ready anymore, so that no new executions are started while the active ones
+// are canceled.
+func beginShutdown() {
+	shuttingDown.Store(true)
+}
+
+// RegisterHealthEndpoints registers the readiness and liveness probes of the preflights:
+//   - /health/preflights/readiness fails if the state persister can't be reached, the startup recovery hasn't
+//     completed or the active preflights are canceled because of a shutdown,
+//   - /health/preflights/liveness fails if the state persister can't be reached.
+func RegisterHealthEndpoints() {
+	exthttp.RegisterHttpHandler("/health/preflights/readiness", func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		handleReadiness(w, r)
+	})
+	exthttp.RegisterHttpHandler("/health/preflights/liveness", func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		handleLiveness(w, r)
+	})
+}
+
+func handleReadiness(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]string{
+		"statePersister":  checkStatePersister(r.Context()),
+		"startupRecovery": "ok",
+		"shutdown":        "ok",
+	}
+	if pendingRecoveries.Load() > 0 {
+		checks["startupRecovery"] = "startup recovery in progress"
+	}
+	if shuttingDown.Load() {
+		checks["shutdown"] = "canceling active preflights"
+	}
+	writeHealthStatus(w, checks)
+}
+
+func handleLiveness(w http.ResponseWriter, r *http.Request) {
+	writeHealthStatus(w, map[string]string{
+		"statePersister": checkStatePersister(r.Context()),
+	})
+}
+
+// checkStatePersister checks the state persister if it implements [state_persister.HealthChecker]. The check isn't
+// traced, as probes call it every few seconds.
+func checkStatePersister(ctx context.Context) string {
+	checker, ok := untraced(statePersister).(state_persister.HealthChecker)
+	if !ok {
+		return "ok"
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := checker.Health(ctx); err != nil {
+		return err.Error()
+	}
+	return "ok"
+}
+
+func writeHealthStatus(w http.ResponseWriter, checks map[string]string) {
+	for _, check := range checks {
+		if check != "ok" {
+			writeResponse(w, response{status: http.StatusServiceUnavailable, body: HealthStatus{Status: "down", Checks: checks}})
+			return
+		}
+	}
+	writeResponse(w, resultResponse(HealthStatus{Status: "up", Checks: checks}))
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unreachableStatePersister struct {
+	state_persister.StatePersister
+}
+
+func (unreachableStatePersister) Health(_ context.Context) error {
+	return errors.New("connection refused")
+}
+
+func probe(t *testing.T, handler http.HandlerFunc) (int, HealthStatus) {
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/health/preflights", nil))
+	var status HealthStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	return recorder.Code, status
+}
+
+func Test_health_is_up(t *testing.T) {
+	code, status := probe(t, handleReadiness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatus{Status: "up", Checks: map[string]string{"statePersister": "ok", "startupRecovery": "ok", "shutdown": "ok"}}, status)
+
+	code, _ = probe(t, handleLiveness)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func Test_health_with_unreachable_state_persister(t *testing.T) {
+	previous := statePersister
+	statePersister = unreachableStatePersister{previous}
+	t.Cleanup(func() { statePersister = previous })
+
+	code, status := probe(t, handleReadiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "down", status.Status)
+	assert.Equal(t, "connection refused", status.Checks["statePersister"])
+
+	code, _ = probe(t, handleLiveness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+type uncheckedStatePersister struct {
+	state_persister.StatePersister
+}
+
+func Test_health_with_state_persister_without_health_check(t *testing.T) {
+	previous := statePersister
+	statePersister = tracingStatePersister{uncheckedStatePersister{state_persister.NewInmemoryStatePersister()}}
+	t.Cleanup(func() { statePersister = previous })
+
+	code, status := probe(t, handleReadiness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", status.Checks["statePersister"])
+}
+
+func Test_readiness_during_startup_recovery(t *testing.T) {
+	done := BeginStartupRecovery()
+	t.Cleanup(done)
+
+	code, status := probe(t, handleReadiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "startup recovery in progress", status.Checks["startupRecovery"])
+	code, _ = probe(t, handleLiveness)
+	assert.Equal(t, http.StatusOK, code, "recovering extensions are alive")
+
+	done()
+	done()
+	code, _ = probe(t, handleReadiness)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func Test_readiness_during_shutdown(t *testing.T) {
+	beginShutdown()
+	t.Cleanup(func() { shuttingDown.Store(false) })
+
+	code, status := probe(t, handleReadiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "canceling active preflights", status.Checks["shutdown"])
+	code, _ = probe(t, handleLiveness)
+	assert.Equal(t, http.StatusOK, code)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/clock"
+)
+
+// heartbeatMonitor checks every interval whether a heartbeat has been recorded within the timeout and calls onTimeout
+// once, if not. In contrast to extheartbeat it runs on a [clock.Clock], so tests can advance time deterministically.
+type heartbeatMonitor struct {
+	clock    clock.Clock
+	interval time.Duration
+	timeout  time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// startHeartbeatMonitor arms the monitor's timer before returning, so advancing a fake clock right afterward is
+// observed by the monitor.
+func startHeartbeatMonitor(clk clock.Clock, interval, timeout time.Duration, onTimeout func()) *heartbeatMonitor {
+	m := &heartbeatMonitor{
+		clock:    clk,
+		interval: interval,
+		timeout:  timeout,
+		last:     clk.Now(),
+		stop:     make(chan struct{}),
+	}
+	log.Debug().
+		Dur("interval", interval).
+		Dur("timeout", timeout).
+		Msg("starting heartbeat")
+
+	timer := clk.NewTimer(interval)
+	go func() {
+		defer timer.Stop()
+		for {
+			select {
+			case <-m.stop:
+				log.Debug().Msg("heartbeat stopped")
+				return
+			case <-timer.C():
+				if last := m.lastHeartbeat(); clk.Since(last) > timeout {
+					log.Warn().
+						Dur("interval", interval).
+						Dur("timeout", timeout).
+						Time("last", last).
+						Msg("no heartbeat received")
+					onTimeout()
+					return
+				}
+				log.Trace().Msg("missed heartbeat")
+				timer.Reset(interval)
+			}
+		}
+	}()
+	return m
+}
+
+func (m *heartbeatMonitor) RecordHeartbeat() {
+	log.Trace().Msg("received heartbeat")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.last = m.clock.Now()
+}
+
+func (m *heartbeatMonitor) lastHeartbeat() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// Stop ends the monitor without calling onTimeout. It is safe to stop a monitor multiple times.
+func (m *heartbeatMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"time"
+)
+
+const (
+	defaultHeartbeatMultiplier = 4
+	minHeartbeatInterval       = 5 * time.Second
+	maxHeartbeatJitter         = 500 * time.Millisecond
+)
+
+// HeartbeatPolicy configures how the SDK detects that the agent stopped polling the status of an execution. Status
+// calls are the heartbeats; once they are missing for too long, the execution is canceled: Cancel is called (if the
+// preflight has one), the persisted state is removed and subsequent status calls report that the preflight was
+// stopped.
+type HeartbeatPolicy struct {
+	// Enabled turns heartbeat monitoring on or off. By default, it is enabled for preflights with Cancel.
+	Enabled *bool
+	// Multiplier of the heartbeat interval after which a missing heartbeat times out. Defaults to 4.
+	Multiplier int
+	// MinInterval is the lower bound for the heartbeat interval, which is derived from the status call interval.
+	// Defaults to 5s.
+	MinInterval time.Duration
+	// Jitter is added to the heartbeat interval to account for network latency and processing time. Defaults to 5% of
+	// the interval, at most 500ms.
+	Jitter *time.Duration
+}
+
+// WithHeartbeatPolicy configures the heartbeat monitoring of the preflight's executions.
+func WithHeartbeatPolicy(policy HeartbeatPolicy) PreflightOption {
+	return func(o *preflightOptions) {
+		o.heartbeat = policy
+	}
+}
+
+func (p HeartbeatPolicy) enabled(hasCancel bool) bool {
+	if p.Enabled != nil {
+		return *p.Enabled
+	}
+	return hasCancel
+}
+
+// intervalAndTimeout derives the heartbeat interval (including jitter) and timeout from the status call interval.
+func (p HeartbeatPolicy) intervalAndTimeout(callInterval time.Duration) (time.Duration, time.Duration) {
+	minInterval := p.MinInterval
+	if minInterval <= 0 {
+		minInterval = minHeartbeatInterval
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultHeartbeatMultiplier
+	}
+
+	interval := max(callInterval, minInterval)
+	timeout := interval * time.Duration(multiplier)
+
+	// We observed heartbeats always narrowly missing the specified interval, hence the jitter.
+	jitter := min(interval/100*5, maxHeartbeatJitter)
+	if p.Jitter != nil {
+		jitter = *p.Jitter
+	}
+	return interval + jitter, timeout
+}
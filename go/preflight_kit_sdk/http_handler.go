@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/klauspost/compress/gzhttp"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kit/exthttp"
+)
+
+// registerHttpHandler registers a handler with the same panic recovery, compression, timeout and request logging as
+// [exthttp.RegisterHttpHandler]. In contrast to it, requests are authenticated before anything else and the request
+// body is left to the handler, so it can be limited and decoded while it is read.
+func registerHttpHandler(path string, handler http.Handler) {
+	http.Handle(path, wrapHttpHandler(handler))
+}
+
+// preflightRoutes holds the routes of all registered preflights, see [Handler].
+var preflightRoutes atomic.Pointer[http.ServeMux]
+
+func init() {
+	resetPreflightRoutes()
+}
+
+// Handler returns the routes of all registered preflights, so they can be mounted in an existing router. The routes
+// keep their full paths, including the base path set by [WithBasePath]:
+//
+//	router.Handle("/preflights/", preflight_kit_sdk.Handler())
+//
+// The routes are registered on the default [http.ServeMux] of exthttp as well.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		preflightRoutes.Load().ServeHTTP(w, r)
+	})
+}
+
+func resetPreflightRoutes() {
+	preflightRoutes.Store(http.NewServeMux())
+}
+
+// route is the handler of a method and path.
+type route struct {
+	// name of the route in error messages, e.g. "start"
+	name    string
+	method  string
+	path    string
+	handler http.Handler
+}
+
+// registerPreflightRoutes registers the routes for their methods only. Requests of a route's path with another method
+// are answered with 405 and the allowed methods, also if a catch-all handler like the index is registered.
+func registerPreflightRoutes(routes []route) {
+	var paths []string
+	allowed := make(map[string][]string)
+	for _, r := range routes {
+		if _, known := allowed[r.path]; !known {
+			paths = append(paths, r.path)
+		}
+		allowed[r.path] = append(allowed[r.path], r.method)
+		registerPreflightHandler(r.method+" "+r.path, r.handler)
+	}
+	for _, path := range paths {
+		registerPreflightHandler(path, methodNotAllowed(allowed[path]))
+	}
+}
+
+func methodNotAllowed(methods []string) http.HandlerFunc {
+	if slices.Contains(methods, http.MethodGet) {
+		methods = append(slices.Clone(methods), http.MethodHead)
+	}
+	allow := strings.Join(methods, ", ")
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", allow)
+		writeResponse(w, *errorResponse(http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed, use %s.", r.Method, allow), nil))
+	}
+}
+
+func registerPreflightHandler(pattern string, handler http.Handler) {
+	wrapped := wrapHttpHandler(handler)
+	http.Handle(pattern, wrapped)
+	preflightRoutes.Load().Handle(pattern, wrapped)
+}
+
+func wrapHttpHandler(handler http.Handler) http.Handler {
+	return exthttp.PanicRecovery(authenticated(gzhttp.GzipHandler(exthttp.RequestTimeoutHeaderAware(logRequest(handler)))))
+}
+
+// logRequest logs requests like [exthttp.LogRequestWithDefaultLogLevel], except for the request body.
+func logRequest(next http.Handler) http.Handler {
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		level := zerolog.InfoLevel
+		if r.Method == http.MethodGet {
+			level = zerolog.DebugLevel
+		}
+
+		hlog.FromRequest(r).Debug().
+			Str("method", r.Method).
+			Stringer("url", r.URL).
+			Int64("req_size", r.ContentLength).
+			Msg("Request received")
+
+		hlog.AccessHandler(func(r *http.Request, status, size int, duration time.Duration) {
+			hlog.FromRequest(r).WithLevel(level).
+				Str("method", r.Method).
+				Stringer("url", r.URL).
+				Int("res_size", size).
+				Int64("req_size", r.ContentLength).
+				Dur("duration", duration).
+				Int("status", status).
+				Msg("")
+		})(next).ServeHTTP(w, r)
+	})
+
+	handler = hlog.RequestIDHandler("req_id", "Request-Id")(handler)
+	handler = hlog.NewHandler(log.Logger)(handler)
+	return handler
+}
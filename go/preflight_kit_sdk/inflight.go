@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrExecutionCanceled is the cause of the context passed to Start and Status when the execution gets canceled while
+// the call is still running. Use context.Cause to distinguish it from a disconnected agent.
+var ErrExecutionCanceled = errors.New("preflight execution canceled")
+
+var (
+	inflightCalls = newInflightRegistry()
+	// inflightCallsGracePeriod is the time Cancel waits for in-flight Start and Status calls to return after their
+	// contexts have been canceled.
+	inflightCallsGracePeriod = 5 * time.Second
+)
+
+type inflightCall struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+type inflightRegistry struct {
+	mu    sync.Mutex
+	calls map[uuid.UUID]map[*inflightCall]struct{}
+}
+
+func newInflightRegistry() *inflightRegistry {
+	return &inflightRegistry{calls: make(map[uuid.UUID]map[*inflightCall]struct{})}
+}
+
+// track derives a cancelable context for a call belonging to the given execution. The returned function must be
+// called as soon as the call has returned.
+func (r *inflightRegistry) track(ctx context.Context, preflightActionExecutionId uuid.UUID) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	call := &inflightCall{cancel: cancel, done: make(chan struct{})}
+
+	r.mu.Lock()
+	if _, ok := r.calls[preflightActionExecutionId]; !ok {
+		r.calls[preflightActionExecutionId] = make(map[*inflightCall]struct{})
+	}
+	r.calls[preflightActionExecutionId][call] = struct{}{}
+	r.mu.Unlock()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.calls[preflightActionExecutionId], call)
+			if len(r.calls[preflightActionExecutionId]) == 0 {
+				delete(r.calls, preflightActionExecutionId)
+			}
+			r.mu.Unlock()
+			close(call.done)
+			cancel(nil)
+		})
+	}
+}
+
+// cancelAndWait cancels the contexts of all in-flight calls of the given execution and waits until they have
+// returned or the grace period has elapsed.
+func (r *inflightRegistry) cancelAndWait(preflightActionExecutionId uuid.UUID, reason string, gracePeriod time.Duration) {
+	r.mu.Lock()
+	calls := make([]*inflightCall, 0, len(r.calls[preflightActionExecutionId]))
+	for call := range r.calls[preflightActionExecutionId] {
+		calls = append(calls, call)
+	}
+	r.mu.Unlock()
+
+	if len(calls) == 0 {
+		return
+	}
+
+	log.Debug().
+		Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+		Str("reason", reason).
+		Int("calls", len(calls)).
+		Msg("canceling in-flight preflight calls")
+
+	cause := fmt.Errorf("%w: %s", ErrExecutionCanceled, reason)
+	for _, call := range calls {
+		call.cancel(cause)
+	}
+
+	deadline := currentClock().After(gracePeriod)
+	for _, call := range calls {
+		select {
+		case <-call.done:
+		case <-deadline:
+			log.Warn().
+				Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+				Str("reason", reason).
+				Dur("gracePeriod", gracePeriod).
+				Msg("in-flight preflight calls did not return within the grace period")
+			return
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	callStart  = "start"
+	callStatus = "status"
+	callCancel = "cancel"
+
+	executionStarted = "started"
+)
+
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	executionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "preflight_executions_total",
+		Help: "Preflight executions by outcome: started, completed, failed, errored or canceled.",
+	}, []string{"preflight_id", "outcome"})
+	callDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "preflight_call_duration_seconds",
+		Help:    "Duration of the start, status and cancel calls.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"preflight_id", "call"})
+	statusPollsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "preflight_status_polls_total",
+		Help: "Status calls per active execution. Removed once the execution has ended.",
+	}, []string{"preflight_id", "execution_id"})
+	heartbeatTimeoutsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "preflight_heartbeat_timeouts_total",
+		Help: "Executions canceled because the agent's heartbeat timed out.",
+	})
+	shutdownCancellationsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "preflight_shutdown_cancellations_total",
+		Help: "Executions canceled because the extension was shut down.",
+	})
+	callbacksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "preflight_callbacks_total",
+		Help: "Callbacks pushing the final status result to the agent by outcome: delivered or failed.",
+	}, []string{"preflight_id", "outcome"})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		executionsTotal,
+		callDuration,
+		statusPollsTotal,
+		heartbeatTimeoutsTotal,
+		shutdownCancellationsTotal,
+		callbacksTotal,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "preflight_active_executions",
+			Help: "Executions with a persisted state.",
+		}, countActiveExecutions),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "preflight_heartbeat_monitors",
+			Help: "Executions whose heartbeat is monitored.",
+		}, countHeartbeatMonitors),
+	)
+}
+
+// countActiveExecutions isn't traced, as every scrape calls it.
+func countActiveExecutions() float64 {
+	ids, err := untraced(statePersister).GetExecutionIds(context.Background())
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to count active preflights.")
+		return 0
+	}
+	return float64(len(ids))
+}
+
+func countHeartbeatMonitors() float64 {
+	count := 0
+	heartbeatMonitors.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return float64(count)
+}
+
+// observeCall records the duration of a call and, if the call has started or ended the execution, its outcome.
+func observeCall(preflightId, call string, began time.Time, outcome string) {
+	callDuration.WithLabelValues(preflightId, call).Observe(currentClock().Since(began).Seconds())
+	switch outcome {
+	case outcomeRequestRejected:
+	case outcomeRunning:
+		if call == callStart {
+			executionsTotal.WithLabelValues(preflightId, executionStarted).Inc()
+		}
+	default:
+		executionsTotal.WithLabelValues(preflightId, outcome).Inc()
+	}
+}
+
+func countStatusPoll(preflightId string, preflightActionExecutionId uuid.UUID) {
+	statusPollsTotal.WithLabelValues(preflightId, preflightActionExecutionId.String()).Inc()
+}
+
+// forgetExecutionMetrics removes the series of an ended execution, so they don't pile up.
+func forgetExecutionMetrics(preflightActionExecutionId uuid.UUID) {
+	statusPollsTotal.DeletePartialMatch(prometheus.Labels{"execution_id": preflightActionExecutionId.String()})
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_metrics_of_an_execution(t *testing.T) {
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(make(chan Call, 10)))
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+	started := testutil.ToFloat64(executionsTotal.WithLabelValues("ExamplePreflightId", executionStarted))
+	canceled := testutil.ToFloat64(executionsTotal.WithLabelValues("ExamplePreflightId", outcomeCanceled))
+	statusCalls := histogramCount(t, "ExamplePreflightId", callStatus)
+
+	startExecution(t, adapter, executionId)
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	assert.Equal(t, started+1, testutil.ToFloat64(executionsTotal.WithLabelValues("ExamplePreflightId", executionStarted)))
+	assert.Equal(t, statusCalls+2, histogramCount(t, "ExamplePreflightId", callStatus))
+	assert.Equal(t, 2.0, testutil.ToFloat64(statusPollsTotal.WithLabelValues("ExamplePreflightId", executionId.String())))
+
+	cancelExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	assert.Equal(t, canceled+1, testutil.ToFloat64(executionsTotal.WithLabelValues("ExamplePreflightId", outcomeCanceled)))
+	assert.Zero(t, statusPollSeries(t, executionId), "the polls of ended executions are removed")
+}
+
+func Test_metrics_of_an_execution_without_cancel(t *testing.T) {
+	p := &noCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](p)
+	executionId := uuid.New()
+
+	startExecution(t, adapter, executionId)
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Equal(t, 1.0, testutil.ToFloat64(statusPollsTotal.WithLabelValues("NoCancelPreflightId", executionId.String())))
+
+	p.completed = true
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Zero(t, statusPollSeries(t, executionId), "the agent won't cancel the execution, so its polls are removed once it has completed")
+}
+
+func Test_metrics_count_failed_executions(t *testing.T) {
+	adapter := newPreflightHttpAdapter[ExampleState](&failingStatusPreflight{NewExamplePreflight(make(chan Call, 10))})
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+	failed := testutil.ToFloat64(executionsTotal.WithLabelValues("ExamplePreflightId", string(preflight_kit_api.Failed)))
+
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	assert.Equal(t, failed+1, testutil.ToFloat64(executionsTotal.WithLabelValues("ExamplePreflightId", string(preflight_kit_api.Failed))))
+}
+
+func Test_metrics_of_heartbeat_monitors(t *testing.T) {
+	fake := useFakeClock(t)
+	executionId := uuid.New()
+	timeouts := testutil.ToFloat64(heartbeatTimeoutsTotal)
+	monitors := countHeartbeatMonitors()
+
+	monitorHeartbeat(executionId, time.Second, 4*time.Second)
+	assert.Equal(t, monitors+1, countHeartbeatMonitors())
+
+	fake.Advance(5 * time.Second)
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(heartbeatTimeoutsTotal) == timeouts+1
+	}, time.Second, 10*time.Millisecond)
+	stopMonitorHeartbeat(executionId)
+	assert.Equal(t, monitors, countHeartbeatMonitors())
+}
+
+func Test_metrics_are_gathered(t *testing.T) {
+	count, err := testutil.GatherAndCount(metricsRegistry, "preflight_active_executions", "preflight_heartbeat_monitors")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func histogramCount(t *testing.T, preflightId, call string) uint64 {
+	families, err := metricsRegistry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "preflight_call_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["preflight_id"] == preflightId && labels["call"] == call {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func statusPollSeries(t *testing.T, executionId uuid.UUID) int {
+	families, err := metricsRegistry.Gather()
+	require.NoError(t, err)
+	count := 0
+	for _, family := range families {
+		if family.GetName() != "preflight_status_polls_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "execution_id" && label.GetValue() == executionId.String() {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// modificationMergers holds a *modificationMerge per execution, which accumulates the modifications of its results.
+var modificationMergers = sync.Map{}
+
+type modificationMerge struct {
+	mu     sync.Mutex
+	merger preflight_kit_api.ModificationMerger
+}
+
+// mergeModifications merges the modifications of a result into the ones the execution returned before, if enabled by
+// [WithModificationMerging]: list additions of values added before are dropped, and conflicts with earlier
+// modifications are reported as a warning in the summary. Invalid modifications are left as they are, the agent
+// rejects them.
+func (a *preflightHttpAdapter[T]) mergeModifications(preflightActionExecutionId uuid.UUID, modifications *preflight_kit_api.ExecutionModifications, summary **preflight_kit_api.Summary) {
+	if !a.options.mergeModifications || modifications == nil || len(*modifications) == 0 {
+		return
+	}
+	value, _ := modificationMergers.LoadOrStore(preflightActionExecutionId, &modificationMerge{})
+	merge := value.(*modificationMerge)
+	merge.mu.Lock()
+	merged, conflicts, err := merge.merger.Merge(*modifications)
+	merge.mu.Unlock()
+	if err != nil {
+		log.Debug().
+			Err(err).
+			Str("preflightActionId", a.description.Id).
+			Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+			Msg("Failed to merge modifications.")
+		return
+	}
+
+	*modifications = merged
+	if len(conflicts) == 0 {
+		return
+	}
+	log.Warn().
+		Str("preflightActionId", a.description.Id).
+		Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+		Interface("conflicts", conflicts).
+		Msg("Preflight returned conflicting modifications.")
+	*summary = preflight_kit_api.ConflictSummary(*summary, conflicts)
+}
+
+func forgetModifications(preflightActionExecutionId uuid.UUID) {
+	modificationMergers.Delete(preflightActionExecutionId)
+}
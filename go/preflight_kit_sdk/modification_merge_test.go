@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modifyingPreflight returns the given modifications from each call.
+type modifyingPreflight struct {
+	*ExamplePreflight
+	start, status, cancel preflight_kit_api.ExecutionModifications
+}
+
+func (p *modifyingPreflight) Start(ctx context.Context, state *ExampleState, request preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error) {
+	result, err := p.ExamplePreflight.Start(ctx, state, request)
+	result.Modifications = extutil.Ptr(p.start)
+	return result, err
+}
+
+func (p *modifyingPreflight) Status(ctx context.Context, state *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	result, err := p.ExamplePreflight.Status(ctx, state)
+	result.Modifications = extutil.Ptr(p.status)
+	result.Summary = &preflight_kit_api.Summary{Level: preflight_kit_api.SummaryLevelInfo, Text: "Ticket updated."}
+	return result, err
+}
+
+func (p *modifyingPreflight) Cancel(ctx context.Context, state *ExampleState) (*preflight_kit_api.CancelResult, error) {
+	result, err := p.ExamplePreflight.Cancel(ctx, state)
+	result.Modifications = extutil.Ptr(p.cancel)
+	return result, err
+}
+
+func modification(t *testing.T, create func(string, any) (preflight_kit_api.ExecutionModification, error), propertyKey string, value any) preflight_kit_api.ExecutionModification {
+	m, err := create(propertyKey, value)
+	require.NoError(t, err)
+	return m
+}
+
+func Test_modifications_are_merged_across_results(t *testing.T) {
+	set, add := preflight_kit_api.NewSetPropertyValue, preflight_kit_api.NewAddValueToListProperty
+	preflight := &modifyingPreflight{
+		ExamplePreflight: NewExamplePreflight(make(chan Call, 10)),
+		start: preflight_kit_api.ExecutionModifications{
+			modification(t, set, "ticket", "OPS-1"),
+			modification(t, add, "approvers", "alice"),
+		},
+		status: preflight_kit_api.ExecutionModifications{
+			modification(t, set, "ticket", "OPS-2"),
+			modification(t, add, "approvers", "alice"),
+			modification(t, add, "approvers", "bob"),
+		},
+		cancel: preflight_kit_api.ExecutionModifications{
+			modification(t, add, "approvers", "bob"),
+			modification(t, add, "approvers", "carol"),
+		},
+	}
+	adapter := newPreflightHttpAdapter[ExampleState](preflight, WithModificationMerging())
+	executionId := uuid.New()
+
+	started := startExecution(t, adapter, executionId)
+	require.NotNil(t, started.Modifications)
+	assert.Equal(t, preflight.start, *started.Modifications)
+	assert.Nil(t, started.Summary)
+
+	status := statusExecution(t, adapter, executionId, started.State)
+	require.NotNil(t, status.Modifications)
+	assert.Equal(t, preflight_kit_api.ExecutionModifications{preflight.status[0], preflight.status[2]}, *status.Modifications, "alice has been added before")
+	assert.Equal(t, &preflight_kit_api.Summary{
+		Level: preflight_kit_api.SummaryLevelWarning,
+		Text:  `Ticket updated. Conflicting modifications: ticket: set to "OPS-2" after "OPS-1"`,
+	}, status.Summary)
+
+	body, err := json.Marshal(preflight_kit_api.CancelPreflightRequestBody{PreflightActionExecutionId: executionId, State: *status.State})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleCancel(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Cancel.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var canceled preflight_kit_api.CancelResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &canceled))
+	require.NotNil(t, canceled.Modifications)
+	assert.Equal(t, preflight_kit_api.ExecutionModifications{preflight.cancel[1]}, *canceled.Modifications)
+	assert.Nil(t, canceled.Summary)
+
+	_, merging := modificationMergers.Load(executionId)
+	assert.False(t, merging, "the modifications are forgotten once the execution has ended")
+}
+
+func Test_modifications_are_only_merged_if_enabled(t *testing.T) {
+	add := preflight_kit_api.NewAddValueToListProperty
+	preflight := &modifyingPreflight{
+		ExamplePreflight: NewExamplePreflight(make(chan Call, 10)),
+		start:            preflight_kit_api.ExecutionModifications{modification(t, add, "approvers", "alice")},
+		status:           preflight_kit_api.ExecutionModifications{modification(t, add, "approvers", "alice")},
+	}
+	adapter := newPreflightHttpAdapter[ExampleState](preflight)
+	executionId := uuid.New()
+
+	started := startExecution(t, adapter, executionId)
+	status := statusExecution(t, adapter, executionId, started.State)
+	require.NotNil(t, status.Modifications)
+	assert.Equal(t, preflight.status, *status.Modifications)
+	_, merging := modificationMergers.Load(executionId)
+	assert.False(t, merging)
+
+	cancelExecution(t, adapter, executionId, *status.State)
+}
+
+// modifyingNoCancelPreflight adds an approver with each status call.
+type modifyingNoCancelPreflight struct {
+	noCancelPreflight
+}
+
+func (p *modifyingNoCancelPreflight) Status(ctx context.Context, state *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	result, err := p.noCancelPreflight.Status(ctx, state)
+	modification, _ := preflight_kit_api.NewAddValueToListProperty("approvers", "alice")
+	result.Modifications = &preflight_kit_api.ExecutionModifications{modification}
+	return result, err
+}
+
+func Test_modifications_of_preflights_without_cancel_are_forgotten(t *testing.T) {
+	preflight := &modifyingNoCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](preflight, WithModificationMerging())
+	require.False(t, adapter.heartbeatEnabled())
+	executionId := uuid.New()
+
+	startExecution(t, adapter, executionId)
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	_, merging := modificationMergers.Load(executionId)
+	require.True(t, merging)
+
+	preflight.completed = true
+	status := statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Empty(t, *status.Modifications, "alice has been added before")
+	_, merging = modificationMergers.Load(executionId)
+	assert.False(t, merging, "the agent won't cancel the execution, so its modifications are forgotten once it has completed")
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import "strings"
+
+// PreflightOption configures how the SDK serves a preflight. Options are passed to [RegisterPreflight].
+type PreflightOption func(*preflightOptions)
+
+type preflightOptions struct {
+	concurrentStatus   bool
+	limits             Limits
+	heartbeat          HeartbeatPolicy
+	contractValidation ContractValidationMode
+	decoding           RequestDecoding
+	basePath           string
+	statusStream       bool
+	mergeModifications bool
+}
+
+func newPreflightOptions(opts ...PreflightOption) preflightOptions {
+	options := preflightOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithConcurrentStatus declares that the preflight's Status method is safe to be invoked concurrently for the same
+// execution. By default, overlapping status calls for the same execution are coalesced into a single invocation.
+func WithConcurrentStatus() PreflightOption {
+	return func(o *preflightOptions) {
+		o.concurrentStatus = true
+	}
+}
+
+// WithBasePath serves the preflight below the given path, e.g. "/preflights" for "/preflights/<id>/start". The base path
+// prefixes the default paths as well as the ones set in the preflight's description.
+func WithBasePath(basePath string) PreflightOption {
+	return func(o *preflightOptions) {
+		o.basePath = strings.TrimSuffix(basePath, "/")
+		if o.basePath != "" && !strings.HasPrefix(o.basePath, "/") {
+			o.basePath = "/" + o.basePath
+		}
+	}
+}
+
+// WithModificationMerging merges the modifications of an execution's Start, Status and Cancel results: list additions
+// of values which have been added before are dropped, and conflicts with earlier modifications, e.g. setting a
+// property to another value, are reported as a warning in the result's summary.
+func WithModificationMerging() PreflightOption {
+	return func(o *preflightOptions) {
+		o.mergeModifications = true
+	}
+}
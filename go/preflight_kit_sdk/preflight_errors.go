@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"errors"
+
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// PreflightError is an error which tells the agent whether the preflight failed or errored. Return it from Start,
+// Status or Cancel, optionally wrapped. Other errors, including [extension_kit.ExtensionError], are reported as
+// [preflight_kit_api.Errored].
+type PreflightError struct {
+	extension_kit.ExtensionError
+	Status preflight_kit_api.PreflightKitErrorStatus
+	err    error
+}
+
+// ToFailedError reports that the preflight has detected a failure, e.g. a failing check. The execution is canceled.
+func ToFailedError(title string, err error) *PreflightError {
+	return &PreflightError{ExtensionError: extension_kit.ToError(title, err), Status: preflight_kit_api.Failed, err: err}
+}
+
+// ToErroredError reports a technical error while executing the preflight. The execution is canceled.
+func ToErroredError(title string, err error) *PreflightError {
+	return &PreflightError{ExtensionError: extension_kit.ToError(title, err), Status: preflight_kit_api.Errored, err: err}
+}
+
+func (e *PreflightError) Error() string {
+	return e.ExtensionError.Error()
+}
+
+func (e *PreflightError) Unwrap() error {
+	return e.err
+}
+
+// toPreflightKitError maps an error returned by a preflight to the error of its result. Errors, which are neither a
+// [PreflightError] nor an [extension_kit.ExtensionError], get the given title and their message as detail.
+func toPreflightKitError(err error, title string) *preflight_kit_api.PreflightKitError {
+	var preflightError *PreflightError
+	if errors.As(err, &preflightError) && preflightError != nil {
+		return newPreflightKitError(preflightError.ExtensionError, preflightError.Status)
+	}
+	var extensionErrorPointer *extension_kit.ExtensionError
+	if errors.As(err, &extensionErrorPointer) && extensionErrorPointer != nil {
+		return newPreflightKitError(*extensionErrorPointer, preflight_kit_api.Errored)
+	}
+	var extensionError extension_kit.ExtensionError
+	if errors.As(err, &extensionError) {
+		return newPreflightKitError(extensionError, preflight_kit_api.Errored)
+	}
+	return newPreflightKitError(extension_kit.ToError(title, err), preflight_kit_api.Errored)
+}
+
+func newPreflightKitError(err extension_kit.ExtensionError, status preflight_kit_api.PreflightKitErrorStatus) *preflight_kit_api.PreflightKitError {
+	if status == "" {
+		status = preflight_kit_api.Errored
+	}
+	return &preflight_kit_api.PreflightKitError{
+		Title:    err.Title,
+		Detail:   err.Detail,
+		Type:     err.Type,
+		Instance: err.Instance,
+		Status:   &status,
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_toPreflightKitError(t *testing.T) {
+	cause := errors.New("details")
+	tests := []struct {
+		name string
+		err  error
+		want preflight_kit_api.PreflightKitError
+	}{
+		{
+			name: "generic error",
+			err:  cause,
+			want: preflight_kit_api.PreflightKitError{Title: "fallback", Detail: new("details"), Status: new(preflight_kit_api.Errored)},
+		},
+		{
+			name: "extension error value",
+			err:  extension_kit.ToError("title", cause),
+			want: preflight_kit_api.PreflightKitError{Title: "title", Detail: new("details"), Status: new(preflight_kit_api.Errored)},
+		},
+		{
+			name: "extension error pointer",
+			err:  new(extension_kit.ToError("title", cause)),
+			want: preflight_kit_api.PreflightKitError{Title: "title", Detail: new("details"), Status: new(preflight_kit_api.Errored)},
+		},
+		{
+			name: "wrapped extension error value",
+			err:  fmt.Errorf("wrapped: %w", extension_kit.ToError("title", cause)),
+			want: preflight_kit_api.PreflightKitError{Title: "title", Detail: new("details"), Status: new(preflight_kit_api.Errored)},
+		},
+		{
+			name: "failed error",
+			err:  ToFailedError("title", cause),
+			want: preflight_kit_api.PreflightKitError{Title: "title", Detail: new("details"), Status: new(preflight_kit_api.Failed)},
+		},
+		{
+			name: "wrapped errored error",
+			err:  fmt.Errorf("wrapped: %w", ToErroredError("title", nil)),
+			want: preflight_kit_api.PreflightKitError{Title: "title", Status: new(preflight_kit_api.Errored)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, &tt.want, toPreflightKitError(tt.err, "fallback"))
+		})
+	}
+}
+
+func TestPreflightError_unwraps_its_cause(t *testing.T) {
+	cause := errors.New("details")
+	err := ToFailedError("title", cause)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "title: details", err.Error())
+}
@@ -4,188 +4,365 @@
 package preflight_kit_sdk
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
-	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
-	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extconversion"
-	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
 	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
 )
 
 const (
-	defaultCallInterval  = "1s"
-	minHeartbeatInterval = 5 * time.Second
+	defaultCallInterval = "1s"
 )
 
 type preflightHttpAdapter[T any] struct {
-	description preflight_kit_api.PreflightDescription
-	preflight   Preflight[T]
-	rootPath    string
+	description   preflight_kit_api.PreflightDescription
+	preflight     Preflight[T]
+	options       preflightOptions
+	rootPath      string
+	statusFlights flightGroup[uuid.UUID, statusOutcome]
+	callSlots     semaphore
+	contract      *contractValidator
 }
 
-func newPreflightHttpAdapter[T any](preflight Preflight[T]) *preflightHttpAdapter[T] {
-	description := getDescriptionWithDefaults(preflight)
+func newPreflightHttpAdapter[T any](preflight Preflight[T], opts ...PreflightOption) *preflightHttpAdapter[T] {
+	options := newPreflightOptions(opts...)
+	description := getDescriptionWithDefaults(preflight, options.basePath)
 	adapter := &preflightHttpAdapter[T]{
 		description: description,
 		preflight:   preflight,
-		rootPath:    fmt.Sprintf("/%s", description.Id),
+		options:     options,
+		rootPath:    fmt.Sprintf("%s/%s", options.basePath, description.Id),
+		callSlots:   newSemaphore(options.limits.MaxInflightCalls),
+		contract:    newContractValidator(options.contractValidation, description.Id),
 	}
 	return adapter
 }
 
-func (a *preflightHttpAdapter[T]) handleGetDescription(w http.ResponseWriter, _ *http.Request, _ []byte) {
-	exthttp.WriteBody(w, a.description)
+func (a *preflightHttpAdapter[T]) handleGetDescription(w http.ResponseWriter, r *http.Request) {
+	ifNoneMatch(func(w http.ResponseWriter, _ *http.Request, _ []byte) {
+		writeResponse(w, resultResponse(a.description))
+	})(w, r, nil)
 }
 
-func (a *preflightHttpAdapter[T]) handleStart(w http.ResponseWriter, r *http.Request, body []byte) {
-	parsedBody, err, done := parseStartRequest(w, body)
-	if done {
-		return
+func (a *preflightHttpAdapter[T]) handleStart(w http.ResponseWriter, r *http.Request) {
+	began := currentClock().Now()
+	ctx, span := a.startServerSpan(r, "preflight.start")
+	defer span.End()
+	resp := a.serveStart(ctx, r.Body)
+	observeCall(a.description.Id, callStart, began, recordOutcome(span, resp, outcomeRunning))
+	writeResponse(w, resp)
+}
+
+func (a *preflightHttpAdapter[T]) serveStart(ctx context.Context, body io.Reader) response {
+	parsedBody, failure := decodeRequestBody[preflight_kit_api.StartPreflightRequestBody](body, a.options.decoding)
+	if failure != nil {
+		return *failure
 	}
-	if err != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to parse request body.", err))
-		return
+	setExecutionId(ctx, parsedBody.PreflightActionExecutionId)
+	if a.supportsCallback() && parsedBody.Callback != nil {
+		if err := validateCallback(parsedBody.Callback); err != nil {
+			return *errorResponse(http.StatusBadRequest, "Invalid callback.", err)
+		}
+	}
+	statusStreams.begin(parsedBody.PreflightActionExecutionId)
+
+	if !admission.tryActivate(a.description.Id, parsedBody.PreflightActionExecutionId, a.options.limits, a.idleTimeout()) {
+		if admission.policy(a.options.limits) == AdmissionReject {
+			return resultResponse(preflight_kit_api.StartResult{
+				State: preflight_kit_api.PreflightState{},
+				Error: capacityExhaustedError(fmt.Sprintf("Too many active executions of preflight %s.", a.description.Id), errNoCapacity),
+			})
+		}
+
+		log.Info().
+			Str("preflightActionId", a.description.Id).
+			Str("preflightActionExecutionId", parsedBody.PreflightActionExecutionId.String()).
+			Msg("no capacity available, queuing preflight start")
+		admission.enqueue(parsedBody, a.idleTimeout())
+		a.monitorHeartbeat(parsedBody.PreflightActionExecutionId)
+		return resultResponse(preflight_kit_api.StartResult{
+			State:   preflight_kit_api.PreflightState{},
+			Summary: &preflight_kit_api.Summary{Level: preflight_kit_api.SummaryLevelInfo, Text: waitingForCapacity},
+		})
+	}
+
+	result, failure := a.start(ctx, parsedBody)
+	if failure != nil {
+		return *failure
+	}
+	return resultResponse(result)
+}
+
+// start invokes the preflight's Start for an execution, which already got an active slot.
+func (a *preflightHttpAdapter[T]) start(ctx context.Context, parsedBody preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, *response) {
+	releaseCallSlot, capacityError := a.acquireCallSlot(ctx)
+	if capacityError != nil {
+		admission.release(parsedBody.PreflightActionExecutionId)
+		return &preflight_kit_api.StartResult{State: preflight_kit_api.PreflightState{}, Error: capacityError}, nil
 	}
 
 	state := a.preflight.NewEmptyState()
-	result, err := a.preflight.Start(r.Context(), &state, parsedBody)
+	labels := executionLabels(parsedBody)
+	callCtx, untrack := inflightCalls.track(ctx, parsedBody.PreflightActionExecutionId)
+	defer untrack()
+	callCtx = withExecutionLogger(callCtx, a.description.Id, parsedBody.PreflightActionExecutionId, labels)
+	result, err := a.preflight.Start(callCtx, &state, parsedBody)
+	releaseCallSlot()
 	if result == nil {
 		result = &preflight_kit_api.StartResult{}
 	}
 
-	if result.State != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Please modify the state using the given state pointer.", err))
-	}
-
 	var convertedState preflight_kit_api.PreflightState
 	conversionErr := extconversion.Convert(state, &convertedState)
 	if conversionErr != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to encode action state.", conversionErr))
-		return
+		admission.release(parsedBody.PreflightActionExecutionId)
+		return nil, errorResponse(http.StatusInternalServerError, "Failed to encode action state.", conversionErr)
 	}
+	returnedState := result.State != nil
 	result.State = convertedState
 
 	if err != nil {
-		var extensionError *extension_kit.ExtensionError
-		isExtensionError := errors.As(err, &extensionError)
-		if !isExtensionError {
-			extensionError = new(extension_kit.ToError("Failed to start preflight.", err))
-		}
-		result.Error = &preflight_kit_api.PreflightKitError{
-			Title:    extensionError.Title,
-			Detail:   extensionError.Detail,
-			Type:     extensionError.Type,
-			Instance: extensionError.Instance,
-		}
+		result.Error = toPreflightKitError(err, "Failed to start preflight.")
+	} else if returnedState {
+		result.Error = stateInResultError("Start")
+	}
+	if result.Error != nil {
+		admission.release(parsedBody.PreflightActionExecutionId)
+	}
+	// Without Cancel, the agent doesn't call back for an execution that failed to start.
+	if result.Error == nil || a.description.Cancel != nil {
+		rememberLabels(parsedBody.PreflightActionExecutionId, labels)
 	}
 
-	if a.description.Cancel != nil {
-		err = statePersister.PersistState(r.Context(), &state_persister.PersistedState{PreflightActionExecutionId: parsedBody.PreflightActionExecutionId, PreflightActionId: a.description.Id, State: convertedState})
+	if a.persistsState() {
+		err = statePersister.PersistState(ctx, &state_persister.PersistedState{PreflightActionExecutionId: parsedBody.PreflightActionExecutionId, PreflightActionId: a.description.Id, State: convertedState, Labels: labels})
 		if err != nil {
-			exthttp.WriteError(w, extension_kit.ToError("Failed to persist preflightAction state.", err))
-			return
-		}
-		if a.description.Status.CallInterval != nil {
-			interval, err := time.ParseDuration(*a.description.Status.CallInterval)
-			if interval < minHeartbeatInterval {
-				interval = minHeartbeatInterval
-			}
-			if err == nil {
-				monitorHeartbeat(parsedBody.PreflightActionExecutionId, interval, interval*4)
-			}
+			admission.release(parsedBody.PreflightActionExecutionId)
+			return nil, errorResponse(http.StatusInternalServerError, "Failed to persist preflightAction state.", err)
 		}
-
+		a.monitorHeartbeat(parsedBody.PreflightActionExecutionId)
 	}
-	exthttp.WriteBody(w, result)
+	a.mergeModifications(parsedBody.PreflightActionExecutionId, result.Modifications, &result.Summary)
+	if result.Error == nil {
+		a.rememberCallback(parsedBody)
+	}
+	return result, nil
 }
 
-func parseStartRequest(w http.ResponseWriter, body []byte) (preflight_kit_api.StartPreflightRequestBody, error, bool) {
-	var parsedBody preflight_kit_api.StartPreflightRequestBody
-	err := json.Unmarshal(body, &parsedBody)
-	if err != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to parse request body.", err))
-		return preflight_kit_api.StartPreflightRequestBody{}, nil, true
+// stateInResultError reports a preflight returning a state in its result. The SDK passes a pointer to the state, which
+// the preflight has to modify instead.
+func stateInResultError(method string) *preflight_kit_api.PreflightKitError {
+	return &preflight_kit_api.PreflightKitError{
+		Title:  fmt.Sprintf("Preflight returned a state from %s.", method),
+		Detail: new(fmt.Sprintf("%s must modify the state via the given state pointer and leave the state of the result empty. The returned state was discarded.", method)),
+		Status: extutil.Ptr(preflight_kit_api.Errored),
 	}
-	return parsedBody, err, false
 }
 
-func (a *preflightHttpAdapter[T]) handleStatus(w http.ResponseWriter, r *http.Request, body []byte) {
-	var parsedBody preflight_kit_api.StatusPreflightRequestBody
-	err := json.Unmarshal(body, &parsedBody)
+// persistsState reports whether the state of executions is persisted, which is needed to cancel them from within the
+// extension, e.g. on heartbeat timeouts or shutdown.
+func (a *preflightHttpAdapter[T]) persistsState() bool {
+	return a.description.Cancel != nil || a.heartbeatEnabled()
+}
+
+func (a *preflightHttpAdapter[T]) heartbeatEnabled() bool {
+	return a.options.heartbeat.enabled(a.description.Cancel != nil)
+}
+
+func (a *preflightHttpAdapter[T]) monitorHeartbeat(preflightActionExecutionId uuid.UUID) {
+	if !a.heartbeatEnabled() || a.description.Status.CallInterval == nil {
+		return
+	}
+	callInterval, err := parseCallInterval(*a.description.Status.CallInterval)
 	if err != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to parse request body.", err))
 		return
 	}
+	interval, timeout := a.options.heartbeat.intervalAndTimeout(callInterval)
+	monitorHeartbeat(preflightActionExecutionId, interval, timeout)
+}
+
+func (a *preflightHttpAdapter[T]) handleStatus(w http.ResponseWriter, r *http.Request) {
+	began := currentClock().Now()
+	ctx, span := a.startServerSpan(r, "preflight.status")
+	defer span.End()
+	resp := a.serveStatus(ctx, r.Body)
+	observeCall(a.description.Id, callStatus, began, recordOutcome(span, resp, outcomeRunning))
+	writeResponse(w, resp)
+}
+
+func (a *preflightHttpAdapter[T]) serveStatus(ctx context.Context, body io.Reader) response {
+	parsedBody, failure := decodeRequestBody[preflight_kit_api.StatusPreflightRequestBody](body, a.options.decoding)
+	if failure != nil {
+		return *failure
+	}
+	setExecutionId(ctx, parsedBody.PreflightActionExecutionId)
+	countStatusPoll(a.description.Id, parsedBody.PreflightActionExecutionId)
 
 	recordHeartbeat(parsedBody.PreflightActionExecutionId)
+	admission.touch(parsedBody.PreflightActionExecutionId, a.idleTimeout())
 
 	if stopEvent := getStopEvent(parsedBody.PreflightActionExecutionId); stopEvent != nil {
-		exthttp.WriteBody(w, preflight_kit_api.StatusResult{
+		return resultResponse(preflight_kit_api.StatusResult{
 			Completed: true,
 			Error: &preflight_kit_api.PreflightKitError{
 				Title:  fmt.Sprintf("Preflight was stopped by extension: %s", stopEvent.reason),
 				Status: extutil.Ptr(preflight_kit_api.Errored),
 			},
 		})
-		return
+	}
+
+	var outcome statusOutcome
+	if a.options.concurrentStatus {
+		outcome = a.publishedStatus(ctx, parsedBody)
+	} else {
+		// Overlapping polls share one invocation. It must not depend on the request of the first poll, which the agent
+		// might abandon while the others are still waiting.
+		var shared bool
+		outcome, shared = a.statusFlights.do(parsedBody.PreflightActionExecutionId, func() statusOutcome {
+			return a.publishedStatus(context.WithoutCancel(ctx), parsedBody)
+		})
+		if shared {
+			log.Debug().
+				Str("preflightActionId", a.description.Id).
+				Str("preflightActionExecutionId", parsedBody.PreflightActionExecutionId.String()).
+				Msg("coalesced overlapping status call")
+		}
+	}
+
+	if outcome.failure != nil {
+		return *outcome.failure
+	}
+	return resultResponse(outcome.result)
+}
+
+// statusOutcome is the outcome of a status call, shared by all coalesced callers. Either result or failure is set.
+type statusOutcome struct {
+	result  *preflight_kit_api.StatusResult
+	failure *response
+}
+
+// publishedStatus is like status, but publishes the result to the status streams of the execution.
+func (a *preflightHttpAdapter[T]) publishedStatus(ctx context.Context, parsedBody preflight_kit_api.StatusPreflightRequestBody) statusOutcome {
+	outcome := a.status(ctx, parsedBody)
+	if outcome.result != nil {
+		publishStatus(parsedBody.PreflightActionExecutionId, *outcome.result)
+	}
+	return outcome
+}
+
+func (a *preflightHttpAdapter[T]) status(ctx context.Context, parsedBody preflight_kit_api.StatusPreflightRequestBody) statusOutcome {
+	if request, ok := admission.queuedStart(parsedBody.PreflightActionExecutionId); ok {
+		return a.startQueued(ctx, request, parsedBody.State)
 	}
 
 	preflight := a.preflight
 
 	state := preflight.NewEmptyState()
-	err = extconversion.Convert(parsedBody.State, &state)
+	err := extconversion.Convert(parsedBody.State, &state)
 	if err != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to parse state.", err))
-		return
+		return statusOutcome{failure: errorResponse(http.StatusBadRequest, "Failed to parse state.", err)}
 	}
 
-	result, err := preflight.Status(r.Context(), &state)
+	releaseCallSlot, capacityError := a.acquireCallSlot(ctx)
+	if capacityError != nil {
+		return statusOutcome{result: &preflight_kit_api.StatusResult{State: &parsedBody.State, Error: capacityError}}
+	}
+	labels := knownLabels(ctx, parsedBody.PreflightActionExecutionId, a.persistsState())
+	callCtx, untrack := inflightCalls.track(ctx, parsedBody.PreflightActionExecutionId)
+	defer untrack()
+	callCtx = withExecutionLogger(callCtx, a.description.Id, parsedBody.PreflightActionExecutionId, labels)
+	result, err := preflight.Status(callCtx, &state)
+	releaseCallSlot()
 	if result == nil {
 		result = &preflight_kit_api.StatusResult{}
 	}
 
-	if result.State != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Please modify the state using the given state pointer.", err))
-	}
-
 	var convertedState preflight_kit_api.PreflightState
 	conversionErr := extconversion.Convert(state, &convertedState)
 	if conversionErr != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to encode preflight state.", conversionErr))
-		return
+		return statusOutcome{failure: errorResponse(http.StatusInternalServerError, "Failed to encode preflight state.", conversionErr)}
 	}
+	returnedState := result.State != nil
 	result.State = &convertedState
 
 	if err != nil {
-		var extensionError *extension_kit.ExtensionError
-		isExtensionError := errors.As(err, &extensionError)
-		if !isExtensionError {
-			extensionError = new(extension_kit.ToError("Failed to read preflight status.", err))
-		}
-		result.Error = &preflight_kit_api.PreflightKitError{
-			Title:    extensionError.Title,
-			Detail:   extensionError.Detail,
-			Type:     extensionError.Type,
-			Instance: extensionError.Instance,
-		}
+		result.Error = toPreflightKitError(err, "Failed to read preflight status.")
+	} else if returnedState {
+		result.Error = stateInResultError("Status")
+	}
+	a.mergeModifications(parsedBody.PreflightActionExecutionId, result.Modifications, &result.Summary)
+	if (result.Completed || result.Error != nil) && a.endExecution(ctx, parsedBody.PreflightActionExecutionId) {
+		return statusOutcome{result: result}
 	}
 
-	if a.description.Cancel != nil {
-		err = statePersister.PersistState(r.Context(), &state_persister.PersistedState{PreflightActionExecutionId: parsedBody.PreflightActionExecutionId, PreflightActionId: a.description.Id, State: convertedState})
+	if a.persistsState() {
+		err = statePersister.PersistState(ctx, &state_persister.PersistedState{PreflightActionExecutionId: parsedBody.PreflightActionExecutionId, PreflightActionId: a.description.Id, State: convertedState, Labels: labels})
 		if err != nil {
-			exthttp.WriteError(w, extension_kit.ToError("Failed to persist preflight state.", err))
-			return
+			return statusOutcome{failure: errorResponse(http.StatusInternalServerError, "Failed to persist preflight state.", err)}
 		}
 	}
-	exthttp.WriteBody(w, result)
+	return statusOutcome{result: result}
+}
+
+// endExecution releases what an execution holds once it has completed, failed or errored, be it reported by Status or
+// pushed by [CompletePreflight]. Without Cancel the agent won't call back once the preflight has ended, so the leftovers
+// are cleaned up right away. Returns whether the persisted state has been deleted.
+func (a *preflightHttpAdapter[T]) endExecution(ctx context.Context, preflightActionExecutionId uuid.UUID) bool {
+	admission.release(preflightActionExecutionId)
+	forgetCallback(preflightActionExecutionId)
+	if a.description.Cancel != nil {
+		return false
+	}
+	forgetExecutionMetrics(preflightActionExecutionId)
+	forgetModifications(preflightActionExecutionId)
+	forgetLabels(preflightActionExecutionId)
+	if !a.heartbeatEnabled() {
+		return false
+	}
+	stopMonitorHeartbeat(preflightActionExecutionId)
+	if err := statePersister.DeleteState(ctx, preflightActionExecutionId); err != nil {
+		log.Debug().
+			Err(err).
+			Str("preflightActionId", a.description.Id).
+			Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+			Msg("Failed to delete preflight state.")
+	}
+	return true
+}
+
+// startQueued starts a queued execution as soon as capacity is available. Until then, the status reports that the
+// execution is waiting for capacity.
+func (a *preflightHttpAdapter[T]) startQueued(ctx context.Context, request preflight_kit_api.StartPreflightRequestBody, state preflight_kit_api.PreflightState) statusOutcome {
+	if !admission.tryActivate(a.description.Id, request.PreflightActionExecutionId, a.options.limits, a.idleTimeout()) {
+		return statusOutcome{result: &preflight_kit_api.StatusResult{
+			State:   &state,
+			Summary: &preflight_kit_api.Summary{Level: preflight_kit_api.SummaryLevelInfo, Text: waitingForCapacity},
+		}}
+	}
+	admission.dequeue(request.PreflightActionExecutionId)
+
+	log.Info().
+		Str("preflightActionId", a.description.Id).
+		Str("preflightActionExecutionId", request.PreflightActionExecutionId.String()).
+		Msg("capacity available, starting queued preflight")
+	startResult, failure := a.start(ctx, request)
+	if failure != nil {
+		return statusOutcome{failure: failure}
+	}
+	return statusOutcome{result: &preflight_kit_api.StatusResult{
+		State:         &startResult.State,
+		Error:         startResult.Error,
+		Modifications: startResult.Modifications,
+		Summary:       startResult.Summary,
+	}}
 }
 
 func (a *preflightHttpAdapter[T]) hasCancel() bool {
@@ -193,46 +370,62 @@ func (a *preflightHttpAdapter[T]) hasCancel() bool {
 	return ok
 }
 
-func (a *preflightHttpAdapter[T]) handleCancel(w http.ResponseWriter, r *http.Request, body []byte) {
+func (a *preflightHttpAdapter[T]) handleCancel(w http.ResponseWriter, r *http.Request) {
+	began := currentClock().Now()
+	ctx, span := a.startServerSpan(r, "preflight.cancel")
+	defer span.End()
+	resp := a.serveCancel(ctx, r.Body)
+	observeCall(a.description.Id, callCancel, began, recordOutcome(span, resp, outcomeCanceled))
+	writeResponse(w, resp)
+}
+
+func (a *preflightHttpAdapter[T]) serveCancel(ctx context.Context, body io.Reader) response {
 	preflight := a.preflight.(PreflightWithCancel[T])
 
-	var parsedBody preflight_kit_api.CancelPreflightRequestBody
-	err := json.Unmarshal(body, &parsedBody)
-	if err != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to parse request body.", err))
-		return
+	parsedBody, failure := decodeRequestBody[preflight_kit_api.CancelPreflightRequestBody](body, a.options.decoding)
+	if failure != nil {
+		return *failure
 	}
+	setExecutionId(ctx, parsedBody.PreflightActionExecutionId)
 
 	stopMonitorHeartbeat(parsedBody.PreflightActionExecutionId)
+	forgetExecutionMetrics(parsedBody.PreflightActionExecutionId)
+	forgetCallback(parsedBody.PreflightActionExecutionId)
+	statusStreams.end(parsedBody.PreflightActionExecutionId, streamEndCanceled, "canceled by agent")
+	inflightCalls.cancelAndWait(parsedBody.PreflightActionExecutionId, "canceled by agent", inflightCallsGracePeriod)
+	defer admission.release(parsedBody.PreflightActionExecutionId)
+	defer forgetModifications(parsedBody.PreflightActionExecutionId)
+	defer forgetLabels(parsedBody.PreflightActionExecutionId)
+
+	if admission.dequeue(parsedBody.PreflightActionExecutionId) {
+		// the preflight was never started, so there is nothing to clean up
+		return resultResponse(preflight_kit_api.CancelResult{})
+	}
 
 	if stopEvent := getStopEvent(parsedBody.PreflightActionExecutionId); stopEvent != nil {
-		exthttp.WriteBody(w, preflight_kit_api.CancelResult{
+		return resultResponse(preflight_kit_api.CancelResult{
 			Error: &preflight_kit_api.PreflightKitError{
-				Title: fmt.Sprintf("Preflight was stopped by extension %s", stopEvent.reason),
+				Title:  fmt.Sprintf("Preflight was stopped by extension %s", stopEvent.reason),
+				Status: extutil.Ptr(preflight_kit_api.Errored),
 			},
 		})
-		return
 	}
 
 	state := preflight.NewEmptyState()
-	err = extconversion.Convert(parsedBody.State, &state)
+	err := extconversion.Convert(parsedBody.State, &state)
 	if err != nil {
-		exthttp.WriteError(w, extension_kit.ToError("Failed to parse state.", err))
-		return
+		return *errorResponse(http.StatusBadRequest, "Failed to parse state.", err)
 	}
 
-	result, err := preflight.Cancel(r.Context(), &state)
+	labels := knownLabels(ctx, parsedBody.PreflightActionExecutionId, true)
+	result, err := preflight.Cancel(withExecutionLogger(ctx, a.description.Id, parsedBody.PreflightActionExecutionId, labels), &state)
 	if result == nil {
 		result = &preflight_kit_api.CancelResult{}
 	}
+	a.mergeModifications(parsedBody.PreflightActionExecutionId, result.Modifications, &result.Summary)
 	if err != nil {
-		extensionError, isExtensionError := err.(extension_kit.ExtensionError)
-		if isExtensionError {
-			exthttp.WriteError(w, extensionError)
-		} else {
-			exthttp.WriteError(w, extension_kit.ToError("Failed to cancel preflight.", err))
-		}
-		return
+		result.Error = toPreflightKitError(err, "Failed to cancel preflight.")
+		return resultResponse(result)
 	}
 
 	folder := fmt.Sprintf("/tmp/steadybit/%v", parsedBody.PreflightActionExecutionId)
@@ -246,34 +439,60 @@ func (a *preflightHttpAdapter[T]) handleCancel(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	err = statePersister.DeleteState(r.Context(), parsedBody.PreflightActionExecutionId)
+	err = statePersister.DeleteState(ctx, parsedBody.PreflightActionExecutionId)
 	if err != nil {
 		log.Warn().
 			Err(err).
 			Str("preflightActionId", a.description.Id).
 			Str("preflightActionExecutionId", parsedBody.PreflightActionExecutionId.String()).
 			Msg("Failed to delete action state.")
-		return
+		return *errorResponse(http.StatusInternalServerError, "Failed to delete preflight state.", err)
 	}
-	exthttp.WriteBody(w, result)
+	return resultResponse(result)
 }
 
 func (a *preflightHttpAdapter[T]) registerHandlers() {
+	routes := a.routes()
+	for _, r := range routes {
+		registeredPaths[r.path] = a.description.Id
+	}
+	registerPreflightRoutes(routes)
+}
 
-	exthttp.RegisterHttpHandler(a.rootPath, a.handleGetDescription)
-	exthttp.RegisterHttpHandler(a.description.Start.Path, a.handleStart)
-	exthttp.RegisterHttpHandler(a.description.Status.Path, a.handleStatus)
+func (a *preflightHttpAdapter[T]) routes() []route {
+	routes := []route{
+		{"description", http.MethodGet, a.rootPath, a.endpoint("", "PreflightDescription", a.handleGetDescription)},
+		{callStart, string(a.description.Start.Method), a.description.Start.Path, a.endpoint("StartPreflightRequestBody", "StartResult", a.handleStart)},
+		{callStatus, string(a.description.Status.Method), a.description.Status.Path, a.endpoint("StatusPreflightRequestBody", "StatusResult", a.handleStatus)},
+	}
 	if a.hasCancel() {
-		exthttp.RegisterHttpHandler(a.description.Cancel.Path, a.handleCancel)
+		routes = append(routes, route{callCancel, string(a.description.Cancel.Method), a.description.Cancel.Path, a.endpoint("CancelPreflightRequestBody", "CancelResult", a.handleCancel)})
+	}
+	if a.options.statusStream {
+		routes = append(routes, route{"status stream", http.MethodGet, a.statusStreamPath(), http.HandlerFunc(a.handleStatusStream)})
 	}
+	return routes
+}
+
+// endpoint adds the request body limit and the contract validation to a handler. The names refer to the request body
+// and the result schema of the preflight kit API spec.
+func (a *preflightHttpAdapter[T]) endpoint(requestBody, result string, handler http.HandlerFunc) http.HandlerFunc {
+	return a.options.decoding.limitRequestBody(a.contract.wrap(requestBody, result, handler))
 }
 
 // getDescriptionWithDefaults wraps the preflight description and adds default paths and methods for prepare, start, status, cancel and metrics.
-func getDescriptionWithDefaults[T any](preflight Preflight[T]) preflight_kit_api.PreflightDescription {
+// All paths are prefixed with the base path.
+func getDescriptionWithDefaults[T any](preflight Preflight[T], basePath string) preflight_kit_api.PreflightDescription {
 	description := preflight.Describe()
-	if description.Start.Path == "" {
-		description.Start.Path = fmt.Sprintf("/%s/start", description.Id)
+	rootPath := fmt.Sprintf("%s/%s", basePath, description.Id)
+	withBasePath := func(path, defaultPath string) string {
+		if path == "" {
+			return fmt.Sprintf("%s/%s", rootPath, defaultPath)
+		}
+		return basePath + path
 	}
+
+	description.Start.Path = withBasePath(description.Start.Path, "start")
 	if description.Start.Method == "" {
 		description.Start.Method = preflight_kit_api.POST
 	}
@@ -282,17 +501,13 @@ func getDescriptionWithDefaults[T any](preflight Preflight[T]) preflight_kit_api
 	}
 
 	if description.Cancel != nil {
-		if description.Cancel.Path == "" {
-			description.Cancel.Path = fmt.Sprintf("/%s/cancel", description.Id)
-		}
+		description.Cancel.Path = withBasePath(description.Cancel.Path, "cancel")
 		if description.Cancel.Method == "" {
 			description.Cancel.Method = preflight_kit_api.POST
 		}
 	}
 
-	if description.Status.Path == "" {
-		description.Status.Path = fmt.Sprintf("/%s/status", description.Id)
-	}
+	description.Status.Path = withBasePath(description.Status.Path, "status")
 	if description.Status.Method == "" {
 		description.Status.Method = preflight_kit_api.POST
 	}
@@ -1,10 +1,23 @@
 package preflight_kit_sdk
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
 	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"maps"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type mockResponseWriter struct {
@@ -29,17 +42,16 @@ func (m *mockResponseWriter) WriteHeader(statusCode int) {
 	m.StatusCode = statusCode
 }
 
-func Test_parseStartRequest(t *testing.T) {
+func Test_decodeRequestBody(t *testing.T) {
 	type args struct {
-		w    http.ResponseWriter
+		w    *mockResponseWriter
 		body []byte
 	}
 	tests := []struct {
-		name  string
-		args  args
-		want  preflight_kit_api.StartPreflightRequestBody
-		want1 error
-		want2 bool
+		name       string
+		args       args
+		want       preflight_kit_api.StartPreflightRequestBody
+		wantStatus int
 	}{
 		//		{
 		//			name: "valid_json",
@@ -89,8 +101,7 @@ func Test_parseStartRequest(t *testing.T) {
 		//				PreflightActionExecutionId: uuid.MustParse("01958b44-6a7f-79dd-900b-e0aedf554be7"),
 		//
 		//			},
-		//			want1: nil,
-		//			want2: false,
+		//			wantStatus: 0,
 		//		},
 		{
 			name: "invalid_json",
@@ -98,17 +109,549 @@ func Test_parseStartRequest(t *testing.T) {
 				w:    &mockResponseWriter{},
 				body: []byte(`{invalid json}`),
 			},
-			want:  preflight_kit_api.StartPreflightRequestBody{},
-			want1: nil,
-			want2: true,
+			want:       preflight_kit_api.StartPreflightRequestBody{},
+			wantStatus: http.StatusBadRequest,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1, got2 := parseStartRequest(tt.args.w, tt.args.body)
-			assert.Equalf(t, tt.want, got, "parseStartRequest(%v, %v)", tt.args.w, tt.args.body)
-			assert.Equalf(t, tt.want1, got1, "parseStartRequest(%v, %v)", tt.args.w, tt.args.body)
-			assert.Equalf(t, tt.want2, got2, "parseStartRequest(%v, %v)", tt.args.w, tt.args.body)
+			got, failure := decodeRequestBody[preflight_kit_api.StartPreflightRequestBody](bytes.NewReader(tt.args.body), RequestDecoding{})
+			assert.Equalf(t, tt.want, got, "decodeRequestBody(%v)", tt.args.body)
+			if tt.wantStatus == 0 {
+				assert.Nil(t, failure)
+				return
+			}
+			require.NotNil(t, failure)
+			writeResponse(tt.args.w, *failure)
+			assert.Equal(t, tt.wantStatus, tt.args.w.StatusCode)
+			assert.Contains(t, string(tt.args.w.Body), "Failed to parse request body.")
 		})
 	}
 }
+
+type blockingStatusPreflight struct {
+	*ExamplePreflight
+	entered     chan struct{}
+	release     chan struct{}
+	statusCalls atomic.Int32
+}
+
+func (p *blockingStatusPreflight) Status(_ context.Context, state *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	p.statusCalls.Add(1)
+	p.entered <- struct{}{}
+	<-p.release
+	state.TestStep = "Status"
+	return &preflight_kit_api.StatusResult{Completed: true}, nil
+}
+
+func runOverlappingStatusCalls(t *testing.T, opts ...PreflightOption) (*blockingStatusPreflight, []*httptest.ResponseRecorder) {
+	p := &blockingStatusPreflight{ExamplePreflight: NewExamplePreflight(make(chan Call, 10)), entered: make(chan struct{}, 2), release: make(chan struct{})}
+	adapter := newPreflightHttpAdapter[ExampleState](p, opts...)
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+
+	body, err := json.Marshal(preflight_kit_api.StatusPreflightRequestBody{PreflightActionExecutionId: executionId, State: preflight_kit_api.PreflightState{}})
+	require.NoError(t, err)
+
+	recorders := []*httptest.ResponseRecorder{httptest.NewRecorder(), httptest.NewRecorder()}
+	var wg sync.WaitGroup
+	call := func(recorder *httptest.ResponseRecorder) {
+		wg.Go(func() {
+			adapter.handleStatus(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Status.Path, bytes.NewReader(body)))
+		})
+	}
+	// the second call starts while the first is within Status and overlaps it until the status is released
+	call(recorders[0])
+	<-p.entered
+	call(recorders[1])
+	if adapter.options.concurrentStatus {
+		<-p.entered
+	} else {
+		require.Eventually(t, func() bool { return adapter.statusFlights.waiting(executionId) == 1 }, 5*time.Second, time.Millisecond)
+	}
+	close(p.release)
+	wg.Wait()
+	return p, recorders
+}
+
+func Test_handleStatus_coalesces_overlapping_calls(t *testing.T) {
+	p, recorders := runOverlappingStatusCalls(t)
+
+	assert.Equal(t, int32(1), p.statusCalls.Load())
+	for _, recorder := range recorders {
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var result preflight_kit_api.StatusResult
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.True(t, result.Completed)
+		assert.Equal(t, "Status", (*result.State)["TestStep"])
+	}
+}
+
+func Test_handleStatus_does_not_coalesce_concurrent_status(t *testing.T) {
+	p, recorders := runOverlappingStatusCalls(t, WithConcurrentStatus())
+
+	assert.Equal(t, int32(2), p.statusCalls.Load())
+	for _, recorder := range recorders {
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+}
+
+func startExecution(t *testing.T, adapter *preflightHttpAdapter[ExampleState], executionId uuid.UUID) preflight_kit_api.StartResult {
+	body, err := json.Marshal(preflight_kit_api.StartPreflightRequestBody{PreflightActionExecutionId: executionId})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleStart(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Start.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var result preflight_kit_api.StartResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	return result
+}
+
+func statusExecution(t *testing.T, adapter *preflightHttpAdapter[ExampleState], executionId uuid.UUID, state preflight_kit_api.PreflightState) preflight_kit_api.StatusResult {
+	body, err := json.Marshal(preflight_kit_api.StatusPreflightRequestBody{PreflightActionExecutionId: executionId, State: state})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleStatus(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Status.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var result preflight_kit_api.StatusResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	return result
+}
+
+func cancelExecution(t *testing.T, adapter *preflightHttpAdapter[ExampleState], executionId uuid.UUID, state preflight_kit_api.PreflightState) {
+	body, err := json.Marshal(preflight_kit_api.CancelPreflightRequestBody{PreflightActionExecutionId: executionId, State: state})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	adapter.handleCancel(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Cancel.Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func Test_handleStart_rejects_executions_over_limit(t *testing.T) {
+	calls := make(chan Call, 10)
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(calls), WithLimits(Limits{MaxActiveExecutions: 1}))
+	first, second := uuid.New(), uuid.New()
+
+	firstResult := startExecution(t, adapter, first)
+	assert.Nil(t, firstResult.Error)
+
+	secondResult := startExecution(t, adapter, second)
+	require.NotNil(t, secondResult.Error)
+	assert.Equal(t, "Preflight capacity exhausted.", secondResult.Error.Title)
+	assert.Equal(t, preflight_kit_api.Errored, *secondResult.Error.Status)
+
+	cancelExecution(t, adapter, first, firstResult.State)
+
+	secondResult = startExecution(t, adapter, second)
+	assert.Nil(t, secondResult.Error)
+	cancelExecution(t, adapter, second, secondResult.State)
+}
+
+func Test_handleStart_queues_executions_over_limit(t *testing.T) {
+	calls := make(chan Call, 10)
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(calls), WithLimits(Limits{MaxActiveExecutions: 1, Admission: AdmissionQueue}))
+	first, second := uuid.New(), uuid.New()
+
+	firstResult := startExecution(t, adapter, first)
+	assert.Nil(t, firstResult.Error)
+
+	secondResult := startExecution(t, adapter, second)
+	assert.Nil(t, secondResult.Error)
+	require.NotNil(t, secondResult.Summary)
+	assert.Equal(t, waitingForCapacity, secondResult.Summary.Text)
+
+	statusResult := statusExecution(t, adapter, second, secondResult.State)
+	assert.False(t, statusResult.Completed)
+	require.NotNil(t, statusResult.Summary)
+	assert.Equal(t, waitingForCapacity, statusResult.Summary.Text)
+	assert.Len(t, calls, 1, "the queued preflight must not have been started yet")
+
+	cancelExecution(t, adapter, first, firstResult.State)
+
+	statusResult = statusExecution(t, adapter, second, secondResult.State)
+	assert.Nil(t, statusResult.Error)
+	assert.Nil(t, statusResult.Summary)
+	assert.Equal(t, "Prepare", (*statusResult.State)["TestStep"])
+
+	cancelExecution(t, adapter, second, *statusResult.State)
+}
+
+func Test_handleCancel_drops_queued_executions(t *testing.T) {
+	calls := make(chan Call, 10)
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(calls), WithLimits(Limits{MaxActiveExecutions: 1, Admission: AdmissionQueue}))
+	first, second := uuid.New(), uuid.New()
+
+	firstResult := startExecution(t, adapter, first)
+	secondResult := startExecution(t, adapter, second)
+	cancelExecution(t, adapter, second, secondResult.State)
+	cancelExecution(t, adapter, first, firstResult.State)
+
+	var names []string
+	for len(calls) > 0 {
+		names = append(names, (<-calls).Name)
+	}
+	assert.Equal(t, []string{"Start", "Cancel"}, names, "the queued preflight must neither be started nor canceled")
+	_, queued := admission.queuedStart(second)
+	assert.False(t, queued)
+}
+
+type noCancelPreflight struct {
+	completed bool
+}
+
+func (p *noCancelPreflight) NewEmptyState() ExampleState {
+	return ExampleState{}
+}
+
+func (p *noCancelPreflight) Describe() preflight_kit_api.PreflightDescription {
+	return preflight_kit_api.PreflightDescription{Id: "NoCancelPreflightId", Status: preflight_kit_api.MutatingEndpointReferenceWithCallInterval{CallInterval: new("1s")}}
+}
+
+func (p *noCancelPreflight) Start(_ context.Context, state *ExampleState, _ preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error) {
+	state.TestStep = "Prepare"
+	return nil, nil
+}
+
+func (p *noCancelPreflight) Status(_ context.Context, state *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	state.TestStep = "Status"
+	return &preflight_kit_api.StatusResult{Completed: p.completed}, nil
+}
+
+func Test_admission_releases_idle_executions(t *testing.T) {
+	fake := useFakeClock(t)
+	p := &noCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](p, WithLimits(Limits{MaxActiveExecutions: 1, IdleTimeout: time.Minute}))
+	first, second := uuid.New(), uuid.New()
+
+	firstResult := startExecution(t, adapter, first)
+	require.Nil(t, firstResult.Error)
+	require.NotNil(t, startExecution(t, adapter, second).Error)
+
+	fake.Advance(time.Minute)
+	secondResult := startExecution(t, adapter, second)
+	assert.Nil(t, secondResult.Error, "the agent of the first execution went away without completing it")
+
+	statusResult := statusExecution(t, adapter, first, firstResult.State)
+	require.NotNil(t, statusResult.Error)
+	assert.Contains(t, statusResult.Error.Title, "idle timeout")
+
+	p.completed = true
+	statusExecution(t, adapter, second, secondResult.State)
+}
+
+func Test_admission_drops_idle_queued_starts(t *testing.T) {
+	fake := useFakeClock(t)
+	p := &noCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](p, WithLimits(Limits{MaxActiveExecutions: 1, Admission: AdmissionQueue, IdleTimeout: time.Minute}))
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	firstResult := startExecution(t, adapter, first)
+	startExecution(t, adapter, second)
+	fake.Advance(30 * time.Second)
+	statusExecution(t, adapter, first, firstResult.State)
+	fake.Advance(40 * time.Second)
+
+	startExecution(t, adapter, third)
+	_, queued := admission.queuedStart(second)
+	assert.False(t, queued, "the queued start has been idle for too long")
+	_, queued = admission.queuedStart(third)
+	assert.True(t, queued, "the first execution is still polled")
+	require.NotNil(t, getStopEvent(second))
+	assert.Equal(t, "idle timeout", getStopEvent(second).reason)
+
+	p.completed = true
+	statusExecution(t, adapter, first, firstResult.State)
+	statusResult := statusExecution(t, adapter, third, preflight_kit_api.PreflightState{})
+	assert.Equal(t, "Prepare", (*statusResult.State)["TestStep"])
+	statusExecution(t, adapter, third, *statusResult.State)
+}
+
+func Test_acquireCallSlot_bounds_the_wait(t *testing.T) {
+	fake := useFakeClock(t)
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(nil), WithLimits(Limits{MaxInflightCalls: 1, Admission: AdmissionQueue, MaxCallSlotWait: time.Minute}))
+	release, capacityError := adapter.acquireCallSlot(context.Background())
+	require.Nil(t, capacityError)
+	defer release()
+
+	waited := make(chan *preflight_kit_api.PreflightKitError)
+	go func() {
+		_, capacityError := adapter.acquireCallSlot(context.WithoutCancel(context.Background()))
+		waited <- capacityError
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(59 * time.Second)
+	select {
+	case <-waited:
+		t.Fatal("gave up before the max call slot wait")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	fake.Advance(time.Second)
+	capacityError = <-waited
+	require.NotNil(t, capacityError)
+	assert.Equal(t, "Preflight capacity exhausted.", capacityError.Title)
+}
+
+func Test_heartbeat_policy_cleans_up_preflights_without_cancel(t *testing.T) {
+	p := &noCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](p, WithHeartbeatPolicy(HeartbeatPolicy{Enabled: new(true)}))
+	registeredPreflights[adapter.description.Id] = p
+	t.Cleanup(func() { delete(registeredPreflights, adapter.description.Id) })
+
+	t.Run("on heartbeat timeout", func(t *testing.T) {
+		executionId := uuid.New()
+		startExecution(t, adapter, executionId)
+		persisted, err := statePersister.GetState(context.Background(), executionId)
+		require.NoError(t, err)
+		assert.Equal(t, "Prepare", persisted.State["TestStep"])
+		_, monitored := heartbeatMonitors.Load(executionId)
+		assert.True(t, monitored)
+
+		CancelPreflight(context.Background(), executionId, "heartbeat timeout")
+
+		_, err = statePersister.GetState(context.Background(), executionId)
+		assert.Error(t, err)
+		_, monitored = heartbeatMonitors.Load(executionId)
+		assert.False(t, monitored)
+		require.NotNil(t, getStopEvent(executionId))
+		assert.Equal(t, "heartbeat timeout", getStopEvent(executionId).reason)
+	})
+
+	t.Run("on completion", func(t *testing.T) {
+		executionId := uuid.New()
+		startResult := startExecution(t, adapter, executionId)
+		p.completed = true
+		statusResult := statusExecution(t, adapter, executionId, startResult.State)
+		assert.True(t, statusResult.Completed)
+
+		_, err := statePersister.GetState(context.Background(), executionId)
+		assert.Error(t, err)
+		_, monitored := heartbeatMonitors.Load(executionId)
+		assert.False(t, monitored)
+	})
+}
+
+type invalidSummaryPreflight struct {
+	*ExamplePreflight
+}
+
+func (p *invalidSummaryPreflight) Status(_ context.Context, _ *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	return &preflight_kit_api.StatusResult{Summary: &preflight_kit_api.Summary{Level: "loud", Text: "Hello"}}, nil
+}
+
+func serveValidatedStatus(t *testing.T, mode ContractValidationMode, body []byte) *httptest.ResponseRecorder {
+	adapter := newPreflightHttpAdapter[ExampleState](&invalidSummaryPreflight{NewExamplePreflight(make(chan Call, 10))}, WithContractValidation(mode))
+	handler := adapter.contract.wrap("StatusPreflightRequestBody", "StatusResult", adapter.handleStatus)
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Status.Path, bytes.NewReader(body)))
+	return recorder
+}
+
+func Test_contractValidation(t *testing.T) {
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+	validBody, err := json.Marshal(preflight_kit_api.StatusPreflightRequestBody{PreflightActionExecutionId: executionId, State: preflight_kit_api.PreflightState{}})
+	require.NoError(t, err)
+
+	t.Run("rejects invalid results", func(t *testing.T) {
+		recorder := serveValidatedStatus(t, ContractValidationReject, validBody)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		var result preflight_kit_api.PreflightKitError
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.Equal(t, "Preflight result violates the preflight kit API.", result.Title)
+		require.NotNil(t, result.Detail)
+		assert.Contains(t, *result.Detail, "/summary/level: ")
+		assert.NotContains(t, *result.Detail, "/summary/text")
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		recorder := serveValidatedStatus(t, ContractValidationReject, []byte(`{"state": {}}`))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		var result preflight_kit_api.PreflightKitError
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.Equal(t, "Request violates the preflight kit API.", result.Title)
+		require.NotNil(t, result.Detail)
+		assert.Contains(t, *result.Detail, "preflightActionExecutionId")
+	})
+
+	t.Run("logs violations only", func(t *testing.T) {
+		recorder := serveValidatedStatus(t, ContractValidationLog, validBody)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var result preflight_kit_api.StatusResult
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.Equal(t, preflight_kit_api.SummaryLevel("loud"), result.Summary.Level)
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(make(chan Call, 10)))
+		assert.Nil(t, adapter.contract)
+	})
+}
+
+type stateReturningPreflight struct {
+	*ExamplePreflight
+}
+
+func (p *stateReturningPreflight) Start(_ context.Context, _ *ExampleState, _ preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error) {
+	return &preflight_kit_api.StartResult{State: preflight_kit_api.PreflightState{"TestStep": "Returned"}}, nil
+}
+
+func (p *stateReturningPreflight) Status(_ context.Context, _ *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	return &preflight_kit_api.StatusResult{State: &preflight_kit_api.PreflightState{"TestStep": "Returned"}}, nil
+}
+
+func (p *stateReturningPreflight) Cancel(_ context.Context, _ *ExampleState) (*preflight_kit_api.CancelResult, error) {
+	return nil, errors.New("cancel failed")
+}
+
+// decodeSingleResponse decodes the response body and checks that nothing was written after it.
+func decodeSingleResponse(t *testing.T, recorder *httptest.ResponseRecorder, v any) {
+	decoder := json.NewDecoder(bytes.NewReader(recorder.Body.Bytes()))
+	require.NoError(t, decoder.Decode(v))
+	var trailing json.RawMessage
+	assert.ErrorIs(t, decoder.Decode(&trailing), io.EOF, "exactly one response body, but got %s after it", trailing)
+}
+
+func Test_handlers_write_a_single_response(t *testing.T) {
+	adapter := newPreflightHttpAdapter[ExampleState](&stateReturningPreflight{NewExamplePreflight(make(chan Call, 10))})
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+
+	t.Run("start returning a state", func(t *testing.T) {
+		result := startExecution(t, adapter, executionId)
+		require.NotNil(t, result.Error)
+		assert.Equal(t, "Preflight returned a state from Start.", result.Error.Title)
+		assert.Equal(t, preflight_kit_api.Errored, *result.Error.Status)
+		assert.NotEqual(t, "Returned", result.State["TestStep"], "the returned state is discarded")
+	})
+
+	t.Run("status returning a state", func(t *testing.T) {
+		result := statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+		require.NotNil(t, result.Error)
+		assert.Equal(t, "Preflight returned a state from Status.", result.Error.Title)
+		assert.Equal(t, preflight_kit_api.Errored, *result.Error.Status)
+	})
+
+	t.Run("cancel failing", func(t *testing.T) {
+		body, err := json.Marshal(preflight_kit_api.CancelPreflightRequestBody{PreflightActionExecutionId: executionId, State: preflight_kit_api.PreflightState{}})
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		adapter.handleCancel(recorder, httptest.NewRequest(http.MethodPost, adapter.description.Cancel.Path, bytes.NewReader(body)))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var result preflight_kit_api.CancelResult
+		decodeSingleResponse(t, recorder, &result)
+		require.NotNil(t, result.Error)
+		assert.Equal(t, "Failed to cancel preflight.", result.Error.Title)
+	})
+
+	for name, handler := range map[string]http.HandlerFunc{
+		"start":  adapter.handleStart,
+		"status": adapter.handleStatus,
+		"cancel": adapter.handleCancel,
+	} {
+		t.Run(name+" with invalid body", func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{invalid json}`)))
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			var result preflight_kit_api.PreflightKitError
+			decodeSingleResponse(t, recorder, &result)
+			assert.Equal(t, "Failed to parse request body.", result.Title)
+		})
+	}
+}
+
+type basePathPreflight struct {
+	*ExamplePreflight
+}
+
+func (p *basePathPreflight) Describe() preflight_kit_api.PreflightDescription {
+	description := p.ExamplePreflight.Describe()
+	description.Id = "BasePathPreflightId"
+	description.Status.Path = "/custom/status"
+	return description
+}
+
+func Test_getDescriptionWithDefaults_uses_base_path(t *testing.T) {
+	adapter := newPreflightHttpAdapter[ExampleState](&basePathPreflight{NewExamplePreflight(nil)}, WithBasePath("preflights/"))
+
+	assert.Equal(t, "/preflights/BasePathPreflightId", adapter.rootPath)
+	assert.Equal(t, "/preflights/BasePathPreflightId/start", adapter.description.Start.Path)
+	assert.Equal(t, "/preflights/custom/status", adapter.description.Status.Path, "explicit paths are prefixed as well")
+	assert.Equal(t, "/preflights/BasePathPreflightId/cancel", adapter.description.Cancel.Path)
+
+	adapter = newPreflightHttpAdapter[ExampleState](NewExamplePreflight(nil))
+	assert.Equal(t, "/ExamplePreflightId/start", adapter.description.Start.Path)
+}
+
+func Test_Handler_serves_registered_preflights(t *testing.T) {
+	require.NoError(t, RegisterPreflight[ExampleState](&basePathPreflight{NewExamplePreflight(nil)}, WithBasePath("/preflights")))
+	t.Cleanup(func() { unregisterPreflight("BasePathPreflightId") })
+
+	assert.Contains(t, GetPreflightList().Preflights, preflight_kit_api.DescribingEndpointReference{
+		Method: preflight_kit_api.GET,
+		Path:   "/preflights/BasePathPreflightId",
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/preflights/", Handler())
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/preflights/BasePathPreflightId", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var description preflight_kit_api.PreflightDescription
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &description))
+	assert.Equal(t, "/preflights/BasePathPreflightId/start", description.Start.Path)
+}
+
+type methodsPreflight struct {
+	*ExamplePreflight
+}
+
+func (p *methodsPreflight) Describe() preflight_kit_api.PreflightDescription {
+	description := p.ExamplePreflight.Describe()
+	description.Id = "MethodsPreflightId"
+	description.Start.Method = preflight_kit_api.PUT
+	description.Cancel = &preflight_kit_api.MutatingEndpointReference{Method: preflight_kit_api.DELETE, Path: "/MethodsPreflightId"}
+	return description
+}
+
+func Test_routes_honor_declared_methods(t *testing.T) {
+	calls := make(chan Call, 10)
+	require.NoError(t, RegisterPreflight[ExampleState](&methodsPreflight{NewExamplePreflight(calls)}))
+	t.Cleanup(func() { unregisterPreflight("MethodsPreflightId") })
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+	serve := func(method, path string, body any) *httptest.ResponseRecorder {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		Handler().ServeHTTP(recorder, httptest.NewRequest(method, path, bytes.NewReader(encoded)))
+		return recorder
+	}
+
+	recorder := serve(http.MethodGet, "/MethodsPreflightId/start", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code, "a GET must not start a preflight")
+	assert.Equal(t, "PUT", recorder.Header().Get("Allow"))
+	assert.Empty(t, calls)
+	recorder = serve(http.MethodPost, "/MethodsPreflightId/start", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = serve(http.MethodPut, "/MethodsPreflightId/start", preflight_kit_api.StartPreflightRequestBody{PreflightActionExecutionId: executionId})
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "Start", (<-calls).Name)
+
+	recorder = serve(http.MethodGet, "/MethodsPreflightId", nil)
+	assert.Equal(t, http.StatusOK, recorder.Code, "the description shares its path with cancel")
+	recorder = serve(http.MethodPost, "/MethodsPreflightId", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, "GET, DELETE, HEAD", recorder.Header().Get("Allow"))
+
+	recorder = serve(http.MethodDelete, "/MethodsPreflightId", preflight_kit_api.CancelPreflightRequestBody{PreflightActionExecutionId: executionId})
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "Cancel", (<-calls).Name)
+}
+
+func unregisterPreflight(preflightId string) {
+	delete(registeredPreflights, preflightId)
+	delete(preflightRootPaths, preflightId)
+	delete(preflightDescriptions, preflightId)
+	maps.DeleteFunc(registeredPaths, func(_, id string) bool { return id == preflightId })
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/extension-kit/exthttp"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// PreflightFilter decides whether a registered preflight is part of the preflight list.
+type PreflightFilter func(description preflight_kit_api.PreflightDescription) bool
+
+// PreflightIds keeps the preflights with one of the given ids, e.g. the ones enabled by configuration.
+func PreflightIds(ids ...string) PreflightFilter {
+	return func(description preflight_kit_api.PreflightDescription) bool {
+		return slices.Contains(ids, description.Id)
+	}
+}
+
+// PreflightLabelMatches keeps the preflights whose label matches the pattern.
+func PreflightLabelMatches(pattern *regexp.Regexp) PreflightFilter {
+	return func(description preflight_kit_api.PreflightDescription) bool {
+		return pattern.MatchString(description.Label)
+	}
+}
+
+// GetPreflightList returns a list of all root endpoints of registered preflights, ordered by their path.
+func GetPreflightList() preflight_kit_api.PreflightList {
+	return GetFilteredPreflightList()
+}
+
+// GetFilteredPreflightList is like [GetPreflightList], but only lists the preflights which pass all filters.
+func GetFilteredPreflightList(filters ...PreflightFilter) preflight_kit_api.PreflightList {
+	var result []preflight_kit_api.DescribingEndpointReference
+	for id, rootPath := range preflightRootPaths {
+		if !passes(preflightDescriptions[id], filters) {
+			continue
+		}
+		result = append(result, preflight_kit_api.DescribingEndpointReference{
+			Method: preflight_kit_api.GET,
+			Path:   rootPath,
+		})
+	}
+	slices.SortFunc(result, func(a, b preflight_kit_api.DescribingEndpointReference) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return preflight_kit_api.PreflightList{
+		Preflights: result,
+	}
+}
+
+// PreflightListHandler serves the filtered preflight list with the revision of exthttp as ETag. A request whose
+// If-None-Match header carries the current revision is answered with 304, so the agent can skip unchanged lists.
+func PreflightListHandler(filters ...PreflightFilter) exthttp.Handler {
+	return ifNoneMatch(exthttp.GetterAsHandler(func() preflight_kit_api.PreflightList {
+		return GetFilteredPreflightList(filters...)
+	}))
+}
+
+func passes(description preflight_kit_api.PreflightDescription, filters []PreflightFilter) bool {
+	for _, filter := range filters {
+		if !filter(description) {
+			return false
+		}
+	}
+	return true
+}
+
+// ifNoneMatch is [exthttp.IfNoneMatchHandler] with the revision of exthttp as ETag, which is sent with the 304 as well.
+func ifNoneMatch(delegate exthttp.Handler) exthttp.Handler {
+	return func(w http.ResponseWriter, r *http.Request, body []byte) {
+		etag := exthttp.Revision()
+		w.Header().Set("ETag", etag)
+		exthttp.IfNoneMatchHandler(func() string { return etag }, delegate)(w, r, body)
+	}
+}
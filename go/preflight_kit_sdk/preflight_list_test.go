@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerListedPreflights registers three preflights with ids like <prefix>-a, as the routes can't be registered twice.
+func registerListedPreflights(t *testing.T, prefix string, opts ...PreflightOption) {
+	ClearRegisteredPreflights()
+	t.Cleanup(ClearRegisteredPreflights)
+	for _, id := range []string{prefix + "-c", prefix + "-a", prefix + "-b"} {
+		require.NoError(t, RegisterPreflight[ExampleState](withDescription(func(description *preflight_kit_api.PreflightDescription) {
+			description.Id = id
+			description.Label = "Label of " + id
+			description.Start.Path = ""
+			description.Status.Path = ""
+			description.Cancel.Path = ""
+		}), opts...))
+	}
+}
+
+func listedPaths(list preflight_kit_api.PreflightList) []string {
+	var paths []string
+	for _, preflight := range list.Preflights {
+		paths = append(paths, preflight.Path)
+	}
+	return paths
+}
+
+func Test_GetPreflightList_is_ordered(t *testing.T) {
+	registerListedPreflights(t, "ordered")
+
+	for range 10 {
+		assert.Equal(t, []string{"/ordered-a", "/ordered-b", "/ordered-c"}, listedPaths(GetPreflightList()))
+	}
+}
+
+func Test_GetFilteredPreflightList(t *testing.T) {
+	registerListedPreflights(t, "filtered")
+
+	assert.Equal(t, []string{"/filtered-a", "/filtered-c"}, listedPaths(GetFilteredPreflightList(PreflightIds("filtered-c", "filtered-a"))))
+	assert.Equal(t, []string{"/filtered-b"}, listedPaths(GetFilteredPreflightList(PreflightLabelMatches(regexp.MustCompile(`-b$`)))))
+	assert.Empty(t, GetFilteredPreflightList(PreflightIds("filtered-a"), PreflightLabelMatches(regexp.MustCompile(`-b$`))).Preflights)
+}
+
+func Test_PreflightListHandler_supports_conditional_requests(t *testing.T) {
+	registerListedPreflights(t, "conditional-list")
+	handler := PreflightListHandler(PreflightIds("conditional-list-a"))
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/preflights", nil), nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	etag := recorder.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	request := httptest.NewRequest(http.MethodGet, "/preflights", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	handler(recorder, request, nil)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+	assert.Equal(t, etag, recorder.Header().Get("ETag"))
+
+	ClearRegisteredPreflights()
+	recorder = httptest.NewRecorder()
+	handler(recorder, request, nil)
+	assert.Equal(t, http.StatusOK, recorder.Code, "registration changes invalidate the ETag")
+}
+
+func Test_description_supports_conditional_requests(t *testing.T) {
+	registerListedPreflights(t, "conditional-description")
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/conditional-description-a", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	etag := recorder.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	request := httptest.NewRequest(http.MethodGet, "/conditional-description-a", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+	assert.Equal(t, etag, recorder.Header().Get("ETag"))
+	assert.Empty(t, recorder.Body.String())
+}
+
+func Test_description_conditional_requests_pass_contract_validation(t *testing.T) {
+	registerListedPreflights(t, "validated-description", WithContractValidation(ContractValidationReject))
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validated-description-a", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	request := httptest.NewRequest(http.MethodGet, "/validated-description-a", nil)
+	request.Header.Set("If-None-Match", recorder.Header().Get("ETag"))
+	recorder = httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+}
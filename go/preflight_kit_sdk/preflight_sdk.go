@@ -10,26 +10,34 @@ import (
 	"os"
 	"reflect"
 	"runtime/coverage"
+	"slices"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/extension-kit/extconversion"
-	"github.com/steadybit/extension-kit/extheartbeat"
 	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/steadybit/extension-kit/extsignals"
 	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
 	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
-	registeredPreflights = make(map[string]any)
-	statePersister       = state_persister.NewInmemoryStatePersister()
-	stopEvents           = make([]stopEvent, 0, 10)
-	stopEventsMu         sync.Mutex
-	heartbeatMonitors    = sync.Map{}
+	registeredPreflights                                 = make(map[string]any)
+	preflightRootPaths                                   = make(map[string]string)
+	preflightDescriptions                                = make(map[string]preflight_kit_api.PreflightDescription)
+	registeredPaths                                      = make(map[string]string)
+	statePersister        state_persister.StatePersister = tracingStatePersister{state_persister.NewInmemoryStatePersister()}
+	stopEvents                                           = make([]stopEvent, 0, 10)
+	stopEventsMu          sync.Mutex
+	heartbeatMonitors     = sync.Map{}
+	// stopEventTTL is the time after which stop events are forgotten. By then the agent has long noticed the stop.
+	stopEventTTL = time.Hour
 )
 
 type stopEvent struct {
@@ -44,9 +52,11 @@ type Preflight[T any] interface {
 	// Describe returns the preflight description.
 	Describe() preflight_kit_api.PreflightDescription
 	// Start is called when the preflight should actually happen.
+	// The context is canceled with [ErrExecutionCanceled] as cause, if the execution gets canceled while Start is still running.
 	// [Details](https://github.com/steadybit/preflight-kit/blob/main/docs/preflight-api.md#start)
 	Start(ctx context.Context, state *T, request preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error)
 	// Status is used to observe the current status of the preflight. This is called periodically by the preflight-kit if time control [preflight_kit_api.TimeControlInternal] or [preflight_kit_api.TimeControlExternal] is used.
+	// The context is canceled with [ErrExecutionCanceled] as cause, if the execution gets canceled while Status is still running.
 	// [Details](https://github.com/steadybit/preflight-kit/blob/main/docs/preflight-api.md#status)
 	Status(ctx context.Context, state *T) (*preflight_kit_api.StatusResult, error)
 }
@@ -65,6 +75,7 @@ func CancelAllActivePreflights(reason string) {
 	}
 	if len(preflightActionExecutionIds) > 0 {
 		log.Warn().Str("reason", reason).Msg("canceling active preflights")
+		shutdownCancellationsTotal.Add(float64(len(preflightActionExecutionIds)))
 	}
 	for _, preflightActionExecutionId := range preflightActionExecutionIds {
 		CancelPreflight(ctx, preflightActionExecutionId, reason)
@@ -72,6 +83,31 @@ func CancelAllActivePreflights(reason string) {
 }
 
 func CancelPreflight(ctx context.Context, preflightActionExecutionId uuid.UUID, reason string) {
+	ctx, span := tracer().Start(ctx, "preflight.cancel", trace.WithAttributes(
+		attributeExecutionId.String(preflightActionExecutionId.String()),
+		attributeCancelReason.String(reason),
+	))
+	defer span.End()
+
+	// Let in-flight Start and Status calls return first, so Cancel neither races them nor works on a stale state.
+	inflightCalls.cancelAndWait(preflightActionExecutionId, reason, inflightCallsGracePeriod)
+	defer admission.release(preflightActionExecutionId)
+	defer forgetExecutionMetrics(preflightActionExecutionId)
+	defer forgetCallback(preflightActionExecutionId)
+	defer forgetModifications(preflightActionExecutionId)
+	defer forgetLabels(preflightActionExecutionId)
+	defer statusStreams.end(preflightActionExecutionId, streamEndCanceled, reason)
+
+	if admission.dequeue(preflightActionExecutionId) {
+		log.Info().
+			Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+			Str("reason", reason).
+			Msg("cancelling queued preflight")
+		markAsStopped(preflightActionExecutionId, reason)
+		stopMonitorHeartbeat(preflightActionExecutionId)
+		return
+	}
+
 	persistedState, err := statePersister.GetState(ctx, preflightActionExecutionId)
 	if err != nil {
 		log.Error().
@@ -93,7 +129,19 @@ func CancelPreflight(ctx context.Context, preflightActionExecutionId uuid.UUID,
 	}
 
 	preflightType := reflect.ValueOf(action)
-	if cancelMethod := preflightType.MethodByName("Cancel"); !cancelMethod.IsNil() {
+	if cancelMethod := preflightType.MethodByName("Cancel"); !cancelMethod.IsValid() {
+		// Without Cancel there is nothing to roll back, but the leftovers of the execution are cleaned up.
+		log.Info().
+			Str("preflightActionId", persistedState.PreflightActionId).
+			Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+			Str("reason", reason).
+			Msg("stopping active preflight")
+
+		markAsStopped(preflightActionExecutionId, reason)
+		stopMonitorHeartbeat(persistedState.PreflightActionExecutionId)
+		deletePersistedState(ctx, persistedState, reason)
+		executionsTotal.WithLabelValues(persistedState.PreflightActionId, outcomeCanceled).Inc()
+	} else {
 		rState := preflightType.MethodByName("NewEmptyState").Call(nil)[0]
 		state := reflect.New(rState.Type()).Interface()
 
@@ -115,7 +163,8 @@ func CancelPreflight(ctx context.Context, preflightActionExecutionId uuid.UUID,
 
 		markAsStopped(preflightActionExecutionId, reason)
 
-		if err := cancelMethod.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(state)})[1].Interface(); err != nil {
+		cancelCtx := withExecutionLogger(ctx, persistedState.PreflightActionId, preflightActionExecutionId, persistedState.Labels)
+		if err := cancelMethod.Call([]reflect.Value{reflect.ValueOf(cancelCtx), reflect.ValueOf(state)})[1].Interface(); err != nil {
 			log.Warn().
 				Str("preflightActionId", persistedState.PreflightActionId).
 				Str("preflightActionExecutionId", preflightActionExecutionId.String()).
@@ -126,14 +175,19 @@ func CancelPreflight(ctx context.Context, preflightActionExecutionId uuid.UUID,
 		}
 
 		stopMonitorHeartbeat(persistedState.PreflightActionExecutionId)
-		if err := statePersister.DeleteState(ctx, persistedState.PreflightActionExecutionId); err != nil {
-			log.Debug().
-				Str("preflightActionId", persistedState.PreflightActionId).
-				Str("preflightActionExecutionId", persistedState.PreflightActionExecutionId.String()).
-				Str("reason", reason).
-				Err(err).
-				Msg("failed deleting persisted state")
-		}
+		deletePersistedState(ctx, persistedState, reason)
+		executionsTotal.WithLabelValues(persistedState.PreflightActionId, outcomeCanceled).Inc()
+	}
+}
+
+func deletePersistedState(ctx context.Context, persistedState *state_persister.PersistedState, reason string) {
+	if err := statePersister.DeleteState(ctx, persistedState.PreflightActionExecutionId); err != nil {
+		log.Debug().
+			Str("preflightActionId", persistedState.PreflightActionId).
+			Str("preflightActionExecutionId", persistedState.PreflightActionExecutionId.String()).
+			Str("reason", reason).
+			Err(err).
+			Msg("failed deleting persisted state")
 	}
 }
 
@@ -143,6 +197,14 @@ func RegisterCoverageEndpoints() {
 	exthttp.RegisterHttpHandler("/coverage/counters", handleCoverageCounters)
 }
 
+// RegisterMetricsEndpoint registers the Prometheus metrics of the preflights, along with those of the default registry.
+func RegisterMetricsEndpoint() {
+	handler := promhttp.HandlerFor(prometheus.Gatherers{metricsRegistry, prometheus.DefaultGatherer}, promhttp.HandlerOpts{})
+	exthttp.RegisterHttpHandler("/metrics", func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		handler.ServeHTTP(w, r)
+	})
+}
+
 func handleCoverageMeta(w http.ResponseWriter, _ *http.Request, _ []byte) {
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.WriteHeader(200)
@@ -159,7 +221,18 @@ func handleCoverageCounters(w http.ResponseWriter, _ *http.Request, _ []byte) {
 	}
 }
 
-func RegisterPreflight[T any](a Preflight[T]) {
+// RegisterPreflight registers the preflight's HTTP endpoints. Use [PreflightOption]s to adjust how the SDK serves it.
+// It returns an error and registers nothing, if the description is invalid (see [ValidatePreflight]) or conflicts with
+// a registered preflight.
+func RegisterPreflight[T any](a Preflight[T], opts ...PreflightOption) error {
+	adapter := newPreflightHttpAdapter(a, opts...)
+	if err := adapter.validate(); err != nil {
+		return err
+	}
+	if err := adapter.validateRegistration(); err != nil {
+		return err
+	}
+
 	//register "StopPreflights" signal handler with the first registered preflight
 	if len(registeredPreflights) == 0 {
 		extsignals.AddSignalHandler(extsignals.SignalHandler{
@@ -167,68 +240,59 @@ func RegisterPreflight[T any](a Preflight[T]) {
 				signalName := extsignals.GetSignalName(signal.(syscall.Signal))
 
 				log.Debug().Str("signal", signalName).Msg("received signal - stopping all active preflights")
+				beginShutdown()
 				CancelAllActivePreflights(fmt.Sprintf("received signal %s", signalName))
 			},
 			Order: extsignals.OrderStopActions,
 			Name:  "StopPreflights",
 		})
 	}
-	adapter := newPreflightHttpAdapter(a)
 	registeredPreflights[adapter.description.Id] = a
+	preflightRootPaths[adapter.description.Id] = adapter.rootPath
+	preflightDescriptions[adapter.description.Id] = adapter.description
 	adapter.registerHandlers()
 	exthttp.BumpRevision()
+	return nil
+}
+
+// MustRegisterPreflight is like [RegisterPreflight], but panics if the preflight can't be registered.
+func MustRegisterPreflight[T any](a Preflight[T], opts ...PreflightOption) {
+	if err := RegisterPreflight(a, opts...); err != nil {
+		panic(err)
+	}
 }
 
-// ClearRegisteredPreflights clears all registered preflights - used for testing. Warning: This will not remove the registered routes from the http server.
+// ClearRegisteredPreflights clears all registered preflights and the routes of [Handler] - used for testing. Warning: This will not remove the registered routes from the http server.
 func ClearRegisteredPreflights() {
+	resetPreflightRoutes()
 	registeredPreflights = make(map[string]any)
+	preflightRootPaths = make(map[string]string)
+	preflightDescriptions = make(map[string]preflight_kit_api.PreflightDescription)
+	registeredPaths = make(map[string]string)
 	exthttp.BumpRevision()
 }
 
-// GetPreflightList returns a list of all root endpoints of registered preflights.
-func GetPreflightList() preflight_kit_api.PreflightList {
-	var result []preflight_kit_api.DescribingEndpointReference
-	for preflightId := range registeredPreflights {
-		result = append(result, preflight_kit_api.DescribingEndpointReference{
-			Method: preflight_kit_api.GET,
-			Path:   fmt.Sprintf("/%s", preflightId),
-		})
-	}
-
-	return preflight_kit_api.PreflightList{
-		Preflights: result,
-	}
-}
-
 func monitorHeartbeat(preflightActionExecutionId uuid.UUID, interval, timeout time.Duration) {
 	monitorHeartbeatWithCallback(preflightActionExecutionId, interval, timeout, func() {
+		heartbeatTimeoutsTotal.Inc()
 		CancelPreflight(context.Background(), preflightActionExecutionId, "heartbeat timeout")
 	})
 }
 
 func monitorHeartbeatWithCallback(preflightActionExecutionId uuid.UUID, interval, timeout time.Duration, callback func()) {
-	// Add some jitter to the interval to account for network latency and processing time,
-	// as we observed heartbeats always narrowly missing the specified interval.
-	extendedInterval := interval + min(interval/100*5, 500*time.Millisecond)
-	ch := make(chan time.Time, 1)
-	monitor := extheartbeat.Notify(ch, extendedInterval, timeout)
+	monitor := startHeartbeatMonitor(currentClock(), interval, timeout, callback)
 	// Stop and replace any monitor already registered for this execution so a repeated
-	// Start (same execution id) can't leak the previous monitor's goroutines. Stop is
+	// Start (same execution id) can't leak the previous monitor's goroutine. Stop is
 	// idempotent, so this is safe even if the previous monitor already stopped.
 	if prev, loaded := heartbeatMonitors.Swap(preflightActionExecutionId, monitor); loaded {
-		prev.(*extheartbeat.Monitor).Stop()
+		prev.(*heartbeatMonitor).Stop()
 	}
-	go func() {
-		for range ch {
-			callback()
-		}
-	}()
 }
 
 func recordHeartbeat(preflightActionExecutionId uuid.UUID) {
 	monitor, _ := heartbeatMonitors.Load(preflightActionExecutionId)
 	if monitor != nil {
-		monitor.(*extheartbeat.Monitor).RecordHeartbeat()
+		monitor.(*heartbeatMonitor).RecordHeartbeat()
 	}
 }
 
@@ -237,26 +301,30 @@ func stopMonitorHeartbeat(preflightActionExecutionId uuid.UUID) {
 	// stop handler and the heartbeat-timeout goroutine) only one gets the monitor; Stop is
 	// idempotent regardless.
 	if monitor, ok := heartbeatMonitors.LoadAndDelete(preflightActionExecutionId); ok {
-		monitor.(*extheartbeat.Monitor).Stop()
+		monitor.(*heartbeatMonitor).Stop()
 	}
 }
 
 func markAsStopped(preflightActionExecutionId uuid.UUID, reason string) {
+	now := currentClock().Now()
 	stopEventsMu.Lock()
 	defer stopEventsMu.Unlock()
+	pruneStopEvents(now)
 	if len(stopEvents) > 100 {
 		stopEvents = stopEvents[1:]
 	}
 	stopEvents = append(stopEvents, stopEvent{
 		preflightActionExecutionId: preflightActionExecutionId,
 		reason:                     reason,
-		timestamp:                  time.Now(),
+		timestamp:                  now,
 	})
 }
 
 func getStopEvent(preflightActionExecutionId uuid.UUID) *stopEvent {
+	now := currentClock().Now()
 	stopEventsMu.Lock()
 	defer stopEventsMu.Unlock()
+	pruneStopEvents(now)
 	for _, event := range stopEvents {
 		if event.preflightActionExecutionId == preflightActionExecutionId {
 			return &event
@@ -264,3 +332,21 @@ func getStopEvent(preflightActionExecutionId uuid.UUID) *stopEvent {
 	}
 	return nil
 }
+
+// recentStopEvents returns a copy of the stop events, which haven't expired yet.
+func recentStopEvents() []stopEvent {
+	now := currentClock().Now()
+	stopEventsMu.Lock()
+	defer stopEventsMu.Unlock()
+	pruneStopEvents(now)
+	return slices.Clone(stopEvents)
+}
+
+// pruneStopEvents drops the stop events older than stopEventTTL. The caller must hold stopEventsMu.
+func pruneStopEvents(now time.Time) {
+	expired := 0
+	for expired < len(stopEvents) && now.Sub(stopEvents[expired].timestamp) > stopEventTTL {
+		expired++
+	}
+	stopEvents = stopEvents[expired:]
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/steadybit/extension-kit/extlogging"
 	"github.com/steadybit/extension-kit/extsignals"
 	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/clock/clocktest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io"
@@ -34,6 +35,7 @@ type PreflightOperations struct {
 	description preflight_kit_api.PreflightDescription
 	calls       <-chan Call
 	preflight   *ExamplePreflight
+	clock       *clocktest.FakeClock
 }
 
 type TestCase struct {
@@ -62,6 +64,7 @@ func Test_SDK(t *testing.T) {
 	}
 	calls := make(chan Call, 1024)
 	defer close(calls)
+	fakeClock := useFakeClock(t)
 
 	serverPort, err := freeport.GetFreePort()
 	require.NoError(t, err)
@@ -69,7 +72,7 @@ func Test_SDK(t *testing.T) {
 	preflight := NewExamplePreflight(calls)
 	go func(preflight *ExamplePreflight) {
 		extlogging.InitZeroLog()
-		RegisterPreflight(preflight)
+		MustRegisterPreflight(preflight)
 		exthttp.RegisterHttpHandler("/", exthttp.GetterAsHandler(GetPreflightList))
 		extsignals.ActivateSignalHandlers()
 		exthttp.Listen(exthttp.ListenOpts{Port: serverPort})
@@ -87,6 +90,7 @@ func Test_SDK(t *testing.T) {
 			executionId: uuid.New(),
 			calls:       calls,
 			preflight:   preflight,
+			clock:       fakeClock,
 		}
 
 		op.resetCalls()
@@ -116,7 +120,7 @@ func testcaseHeartbeatTimeout(t *testing.T, op PreflightOperations) {
 	state := result.State
 	op.resetCalls()
 
-	time.Sleep(25 * time.Second)
+	op.clock.Advance(25 * time.Second)
 	op.assertCall(t, "Cancel", ANY_ARG)
 
 	statusResult, _ := op.statusResult(t, state)
@@ -128,7 +132,7 @@ func testcaseHeartbeatTimeout(t *testing.T, op PreflightOperations) {
 func testCaseStatusWithGenericError(t *testing.T, op PreflightOperations) {
 	op.preflight.statusError = fmt.Errorf("this is a test error")
 	statusResult, err := op.statusResult(t, preflight_kit_api.PreflightState{})
-	assert.Equal(t, &preflight_kit_api.PreflightKitError{Title: "Failed to read preflight status.", Detail: new("this is a test error")}, statusResult.Error)
+	assert.Equal(t, &preflight_kit_api.PreflightKitError{Title: "Failed to read preflight status.", Detail: new("this is a test error"), Status: new(preflight_kit_api.Errored)}, statusResult.Error)
 	assert.Nil(t, err)
 	op.assertCall(t, "Status", ANY_ARG)
 }
@@ -136,7 +140,7 @@ func testCaseStatusWithGenericError(t *testing.T, op PreflightOperations) {
 func testCaseStatusWithExtensionKitError(t *testing.T, op PreflightOperations) {
 	op.preflight.statusError = new(extension_kit.ToError("this is a test error", errors.New("with some details")))
 	statusResult, err := op.statusResult(t, preflight_kit_api.PreflightState{})
-	assert.Equal(t, &preflight_kit_api.PreflightKitError{Title: "this is a test error", Detail: new("with some details")}, statusResult.Error)
+	assert.Equal(t, &preflight_kit_api.PreflightKitError{Title: "this is a test error", Detail: new("with some details"), Status: new(preflight_kit_api.Errored)}, statusResult.Error)
 	assert.Nil(t, err)
 	op.assertCall(t, "Status", ANY_ARG)
 }
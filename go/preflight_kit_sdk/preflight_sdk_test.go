@@ -1,7 +1,10 @@
 package preflight_kit_sdk
 
 import (
+	"context"
 	"github.com/google/uuid"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/clock"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/clock/clocktest"
 	"github.com/stretchr/testify/assert"
 	"runtime"
 	"sync"
@@ -11,7 +14,7 @@ import (
 
 // TestMonitorHeartbeat_restart_does_not_leak_goroutines verifies that repeatedly starting a
 // heartbeat monitor for the same execution id (e.g. a retried Start) replaces and stops the
-// previous monitor rather than leaking its goroutines. Each monitor spins two goroutines;
+// previous monitor rather than leaking its goroutines. Each monitor spins a goroutine;
 // without the Swap-and-Stop they would accumulate.
 func TestMonitorHeartbeat_restart_does_not_leak_goroutines(t *testing.T) {
 	id := uuid.New()
@@ -39,34 +42,135 @@ func TestStopEvents_concurrent_access(t *testing.T) {
 	wg.Wait()
 }
 
+func useFakeClock(t *testing.T) *clocktest.FakeClock {
+	fake := clocktest.NewFakeClock(time.Now())
+	SetClock(fake)
+	t.Cleanup(func() { SetClock(clock.Real()) })
+	return fake
+}
+
 // This test reproduced an issue in which new heartbeats
 // would not be processed anymore and led to a cancel the preflight.
 func TestHeartbeat_should_not_timeout(t *testing.T) {
-	stop := make(chan any)
-	id, err := uuid.NewUUID()
-	assert.NoError(t, err)
+	fake := useFakeClock(t)
+	id := uuid.New()
+	timedOut := make(chan any, 1)
+	monitorHeartbeatWithCallback(id, 1*time.Second, 4*time.Second, func() {
+		timedOut <- nil
+	})
+	defer stopMonitorHeartbeat(id)
+
+	for range 10 {
+		fake.BlockUntil(1)
+		fake.Advance(1 * time.Second)
+		recordHeartbeat(id)
+	}
+
+	select {
+	case <-timedOut:
+		assert.Fail(t, "heartbeat timeout called")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
 
+func TestHeartbeat_should_timeout_without_heartbeats(t *testing.T) {
+	fake := useFakeClock(t)
+	id := uuid.New()
+	timedOut := make(chan any, 1)
 	monitorHeartbeatWithCallback(id, 1*time.Second, 4*time.Second, func() {
-		stop <- nil
+		timedOut <- nil
 	})
+	defer stopMonitorHeartbeat(id)
+
+	fake.Advance(5 * time.Second)
+
+	select {
+	case <-timedOut:
+	case <-time.After(time.Second):
+		assert.Fail(t, "heartbeat timeout not called")
+	}
+}
+
+func TestStopEvents_expire_after_ttl(t *testing.T) {
+	fake := useFakeClock(t)
+	id := uuid.New()
+	markAsStopped(id, "test")
+
+	fake.Advance(stopEventTTL)
+	event := getStopEvent(id)
+	if assert.NotNil(t, event) {
+		assert.Equal(t, fake.Now().Add(-stopEventTTL), event.timestamp)
+	}
 
+	fake.Advance(time.Second)
+	assert.Nil(t, getStopEvent(id))
+}
+
+func TestInflightCalls_cancelAndWait_cancels_running_calls(t *testing.T) {
+	id := uuid.New()
+	ctx, done := inflightCalls.track(context.Background(), id)
+
+	returned := make(chan any)
 	go func() {
-		for {
-			select {
-			case <-stop:
-				return
-			case <-time.After(1 * time.Second):
-				recordHeartbeat(id)
-			}
-		}
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		// marked as returned before untracking, as cancelAndWait may return right after done
+		close(returned)
+		done()
 	}()
 
+	inflightCalls.cancelAndWait(id, "test", time.Minute)
+
 	select {
-	case _, ok := <-stop:
-		if ok {
-			assert.Fail(t, "heartbeat close called")
-		}
-	case <-time.After(10 * time.Second):
-		close(stop)
+	case <-returned:
+	default:
+		assert.Fail(t, "cancelAndWait must wait for the in-flight call to return")
 	}
+	assert.ErrorIs(t, context.Cause(ctx), ErrExecutionCanceled)
+	assert.ErrorContains(t, context.Cause(ctx), "test")
+}
+
+func TestInflightCalls_cancelAndWait_respects_grace_period(t *testing.T) {
+	id := uuid.New()
+	_, done := inflightCalls.track(context.Background(), id)
+	defer done()
+
+	fake := useFakeClock(t)
+	returned := make(chan any)
+	go func() {
+		inflightCalls.cancelAndWait(id, "test", 100*time.Millisecond)
+		close(returned)
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(100 * time.Millisecond)
+	<-returned
+}
+
+func TestInflightCalls_other_executions_are_not_canceled(t *testing.T) {
+	ctx, done := inflightCalls.track(context.Background(), uuid.New())
+	defer done()
+
+	inflightCalls.cancelAndWait(uuid.New(), "test", time.Second)
+	assert.NoError(t, ctx.Err())
+}
+
+func TestHeartbeatPolicy_intervalAndTimeout(t *testing.T) {
+	interval, timeout := HeartbeatPolicy{}.intervalAndTimeout(time.Second)
+	assert.Equal(t, 5*time.Second+250*time.Millisecond, interval, "default min interval and jitter")
+	assert.Equal(t, 20*time.Second, timeout, "default multiplier")
+
+	interval, timeout = HeartbeatPolicy{}.intervalAndTimeout(time.Minute)
+	assert.Equal(t, time.Minute+500*time.Millisecond, interval, "jitter is capped")
+	assert.Equal(t, 4*time.Minute, timeout)
+
+	interval, timeout = HeartbeatPolicy{Multiplier: 2, MinInterval: time.Second, Jitter: new(time.Duration(0))}.intervalAndTimeout(2 * time.Second)
+	assert.Equal(t, 2*time.Second, interval)
+	assert.Equal(t, 4*time.Second, timeout)
+}
+
+func TestHeartbeatPolicy_enabled(t *testing.T) {
+	assert.True(t, HeartbeatPolicy{}.enabled(true))
+	assert.False(t, HeartbeatPolicy{}.enabled(false))
+	assert.True(t, HeartbeatPolicy{Enabled: new(true)}.enabled(false))
+	assert.False(t, HeartbeatPolicy{Enabled: new(false)}.enabled(true))
 }
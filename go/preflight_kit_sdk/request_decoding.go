@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const defaultMaxRequestBodyBytes = 10 << 20
+
+// RequestDecoding configures how the SDK reads the request bodies of the preflight's endpoints.
+type RequestDecoding struct {
+	// MaxBodyBytes is the maximum size of a request body. Larger requests are rejected with 413. Defaults to 10 MiB.
+	MaxBodyBytes int64
+	// Strict rejects request bodies with fields unknown to the preflight kit API of this SDK, so that version drift
+	// between agent and extension is detected early.
+	Strict bool
+}
+
+// WithRequestDecoding configures how the request bodies of the preflight's endpoints are read.
+func WithRequestDecoding(decoding RequestDecoding) PreflightOption {
+	return func(o *preflightOptions) {
+		o.decoding = decoding
+	}
+}
+
+func (d RequestDecoding) maxBodyBytes() int64 {
+	if d.MaxBodyBytes > 0 {
+		return d.MaxBodyBytes
+	}
+	return defaultMaxRequestBodyBytes
+}
+
+// limitRequestBody rejects requests announcing a body above the limit right away and stops reading other bodies at the
+// limit.
+func (d RequestDecoding) limitRequestBody(next http.HandlerFunc) http.HandlerFunc {
+	maxBodyBytes := d.maxBodyBytes()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBodyBytes {
+			writeResponse(w, *requestBodyTooLarge(maxBodyBytes))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		next(w, r)
+	}
+}
+
+// decodeRequestBody decodes the body of a request while it is read. A body above the limit is answered with 413,
+// other failures with 400.
+func decodeRequestBody[B any](body io.Reader, decoding RequestDecoding) (B, *response) {
+	var parsedBody B
+	decoder := json.NewDecoder(body)
+	if decoding.Strict {
+		decoder.DisallowUnknownFields()
+	}
+	err := decoder.Decode(&parsedBody)
+	if err == nil && decoder.More() {
+		err = errors.New("unexpected data after the JSON body")
+	}
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return parsedBody, requestBodyTooLarge(maxBytesError.Limit)
+		}
+		return parsedBody, errorResponse(http.StatusBadRequest, "Failed to parse request body.", err)
+	}
+	return parsedBody, nil
+}
+
+func requestBodyTooLarge(maxBodyBytes int64) *response {
+	return errorResponse(http.StatusRequestEntityTooLarge, "Request body too large.", fmt.Errorf("the request body exceeds the limit of %d bytes", maxBodyBytes))
+}
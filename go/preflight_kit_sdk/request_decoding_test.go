@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveStatusWithDecoding(t *testing.T, body io.Reader, contentLength int64, opts ...PreflightOption) *httptest.ResponseRecorder {
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(make(chan Call, 10)), opts...)
+	r := httptest.NewRequest(http.MethodPost, adapter.description.Status.Path, body)
+	r.ContentLength = contentLength
+	recorder := httptest.NewRecorder()
+	adapter.endpoint("StatusPreflightRequestBody", "StatusResult", adapter.handleStatus)(recorder, r)
+	return recorder
+}
+
+func statusBody(t *testing.T, executionId uuid.UUID, extra string) string {
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+	return `{"preflightActionExecutionId": "` + executionId.String() + `", "state": {}` + extra + `}`
+}
+
+func assertErrorResponse(t *testing.T, recorder *httptest.ResponseRecorder, status int, title string) preflight_kit_api.PreflightKitError {
+	assert.Equal(t, status, recorder.Code)
+	var result preflight_kit_api.PreflightKitError
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	assert.Equal(t, title, result.Title)
+	return result
+}
+
+func Test_requestDecoding_rejects_announced_large_bodies(t *testing.T) {
+	body := &countingReader{Reader: strings.NewReader(statusBody(t, uuid.New(), ""))}
+	recorder := serveStatusWithDecoding(t, body, 1024, WithRequestDecoding(RequestDecoding{MaxBodyBytes: 100}))
+	assertErrorResponse(t, recorder, http.StatusRequestEntityTooLarge, "Request body too large.")
+	assert.Zero(t, body.read, "the body must not be read")
+}
+
+func Test_requestDecoding_rejects_streamed_large_bodies(t *testing.T) {
+	body := statusBody(t, uuid.New(), `, "padding": "`+strings.Repeat("x", 200)+`"`)
+	recorder := serveStatusWithDecoding(t, strings.NewReader(body), -1, WithRequestDecoding(RequestDecoding{MaxBodyBytes: 100}))
+	assertErrorResponse(t, recorder, http.StatusRequestEntityTooLarge, "Request body too large.")
+
+	recorder = serveStatusWithDecoding(t, strings.NewReader(body), -1, WithRequestDecoding(RequestDecoding{MaxBodyBytes: 100}), WithContractValidation(ContractValidationReject))
+	assertErrorResponse(t, recorder, http.StatusRequestEntityTooLarge, "Request body too large.")
+}
+
+func Test_requestDecoding_strict_mode(t *testing.T) {
+	body := statusBody(t, uuid.New(), `, "unknownField": true`)
+
+	recorder := serveStatusWithDecoding(t, strings.NewReader(body), -1)
+	assert.Equal(t, http.StatusOK, recorder.Code, "unknown fields are ignored by default")
+
+	recorder = serveStatusWithDecoding(t, strings.NewReader(body), -1, WithRequestDecoding(RequestDecoding{Strict: true}))
+	result := assertErrorResponse(t, recorder, http.StatusBadRequest, "Failed to parse request body.")
+	require.NotNil(t, result.Detail)
+	assert.Contains(t, *result.Detail, `unknown field "unknownField"`)
+}
+
+func Test_requestDecoding_rejects_trailing_data(t *testing.T) {
+	body := statusBody(t, uuid.New(), "") + `{}`
+	recorder := serveStatusWithDecoding(t, strings.NewReader(body), -1)
+	assertErrorResponse(t, recorder, http.StatusBadRequest, "Failed to parse request body.")
+}
+
+type countingReader struct {
+	io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.read += n
+	return n, err
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	extension_kit "github.com/steadybit/extension-kit"
+)
+
+// response is the one response of a handler. Handlers compute it and hand it to writeResponse, so that no code path
+// can write twice or not at all.
+//
+// The status codes are used consistently:
+//   - 200 for results, including errors reported by the preflight in the result's error,
+//   - 400 for requests which can't be parsed,
+//   - 500 for failures of the SDK, e.g. encoding or persisting the state.
+type response struct {
+	status int
+	body   any
+}
+
+func resultResponse(result any) response {
+	return response{status: http.StatusOK, body: result}
+}
+
+func errorResponse(status int, title string, err error) *response {
+	return &response{status: status, body: extension_kit.ToError(title, err)}
+}
+
+func writeResponse(w http.ResponseWriter, resp response) {
+	if extensionError, ok := resp.body.(extension_kit.ExtensionError); ok {
+		logEvent := log.Error()
+		if resp.status < http.StatusInternalServerError {
+			logEvent = log.Warn()
+		}
+		if extensionError.Detail != nil {
+			logEvent.Str("details", *extensionError.Detail)
+		}
+		logEvent.Int("status", resp.status).Msg(extensionError.Title)
+	}
+
+	body, err := json.Marshal(resp.body)
+	if err != nil {
+		log.Err(err).Msg("Failed to write response body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(extension_kit.ToError("Failed to write response body.", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.status)
+	_, _ = w.Write(append(body, '\n'))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRegisterPreflightBumpsRevision(t *testing.T) {
@@ -15,7 +16,7 @@ func TestRegisterPreflightBumpsRevision(t *testing.T) {
 	t.Cleanup(ClearRegisteredPreflights)
 
 	before := exthttp.Revision()
-	RegisterPreflight[ExampleState](NewExamplePreflight(make(chan Call, 10)))
+	require.NoError(t, RegisterPreflight[ExampleState](NewExamplePreflight(make(chan Call, 10))))
 	assert.NotEqual(t, before, exthttp.Revision(), "RegisterPreflight must bump the index revision")
 }
 
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"sync"
+)
+
+// flightGroup coalesces concurrent calls with the same key into a single invocation, whose result is shared by all
+// callers.
+type flightGroup[K comparable, V any] struct {
+	mu      sync.Mutex
+	flights map[K]*flight[V]
+}
+
+type flight[V any] struct {
+	done    chan struct{}
+	waiters int
+	result  V
+	// panicked is the value the invocation panicked with, which is raised again in every waiting caller.
+	panicked any
+}
+
+// do invokes fn unless an invocation for the key is already running, in which case it waits for and returns that
+// invocation's result. shared reports whether the result was produced by another caller's invocation. If the invocation
+// panics, all callers panic with the same value.
+func (g *flightGroup[K, V]) do(key K, fn func() V) (result V, shared bool) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[K]*flight[V])
+	}
+	if f, ok := g.flights[key]; ok {
+		f.waiters++
+		g.mu.Unlock()
+		<-f.done
+		if f.panicked != nil {
+			panic(f.panicked)
+		}
+		return f.result, true
+	}
+	f := &flight[V]{done: make(chan struct{})}
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		recovered := recover()
+		f.panicked = recovered
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+	f.result = fn()
+	return f.result, false
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waiting returns the number of callers waiting for the running invocation for the key.
+func (g *flightGroup[K, V]) waiting(key K) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if f, ok := g.flights[key]; ok {
+		return f.waiters
+	}
+	return 0
+}
+
+func Test_flightGroup_propagates_panics_to_waiters(t *testing.T) {
+	var g flightGroup[string, int]
+	release := make(chan struct{})
+	invoked := make(chan struct{})
+
+	invokerPanic := make(chan any, 1)
+	go func() {
+		defer func() { invokerPanic <- recover() }()
+		g.do("key", func() int {
+			close(invoked)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-invoked
+
+	waiterPanic := make(chan any, 1)
+	go func() {
+		defer func() { waiterPanic <- recover() }()
+		g.do("key", func() int { return 1 })
+	}()
+	require.Eventually(t, func() bool { return g.waiting("key") == 1 }, 5*time.Second, time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "boom", <-invokerPanic)
+	assert.Equal(t, "boom", <-waiterPanic, "waiters must not get a zero result")
+
+	result, shared := g.do("key", func() int { return 2 })
+	assert.Equal(t, 2, result, "the key is free again")
+	assert.False(t, shared)
+}
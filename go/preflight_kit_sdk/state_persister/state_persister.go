@@ -17,6 +17,8 @@ type PersistedState struct {
 	PreflightActionExecutionId uuid.UUID
 	PreflightActionId          string
 	State                      preflight_kit_api.PreflightState
+	// Labels describe the experiment execution, e.g. its experiment key. They are added to the execution's logger.
+	Labels map[string]string
 }
 
 type StatePersister interface {
@@ -26,6 +28,13 @@ type StatePersister interface {
 	DeleteState(ctx context.Context, executionId uuid.UUID) error
 }
 
+// HealthChecker can be implemented by a StatePersister to be checked by the health probes of the SDK. Persisters without
+// it are considered reachable.
+type HealthChecker interface {
+	// Health returns an error if the persister can't be reached, e.g. because its database is down.
+	Health(ctx context.Context) error
+}
+
 func NewInmemoryStatePersister() StatePersister {
 	return &inmemoryStatePersister{states: sync.Map{}}
 }
@@ -60,3 +69,7 @@ func (p *inmemoryStatePersister) DeleteState(_ context.Context, executionId uuid
 	p.states.Delete(executionId)
 	return nil
 }
+
+func (p *inmemoryStatePersister) Health(_ context.Context) error {
+	return nil
+}
@@ -21,9 +21,9 @@ func TestInmemoryStatePersister_basics(t *testing.T) {
 	exe1 := uuid.New()
 	exe2 := uuid.New()
 
-	err := persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 1}})
+	err := persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 1}, nil})
 	require.NoError(t, err)
-	err = persister.PersistState(context.Background(), &PersistedState{exe2, "preflight-1", preflight_kit_api.PreflightState{"test": 2}})
+	err = persister.PersistState(context.Background(), &PersistedState{exe2, "preflight-1", preflight_kit_api.PreflightState{"test": 2}, map[string]string{"experimentKey": "ADM-9"}})
 	require.NoError(t, err)
 
 	executionIds, err := persister.GetExecutionIds(context.Background())
@@ -41,7 +41,7 @@ func TestInmemoryStatePersister_basics(t *testing.T) {
 func TestInmemoryStatePersister_should_ignore_not_found(t *testing.T) {
 	persister := NewInmemoryStatePersister()
 	exe1 := uuid.New()
-	err := persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 1}})
+	err := persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 1}, nil})
 	require.NoError(t, err)
 
 	err = persister.DeleteState(context.Background(), uuid.New())
@@ -55,10 +55,10 @@ func TestInmemoryStatePersister_should_ignore_not_found(t *testing.T) {
 func TestInmemoryStatePersister_should_update_existing_values(t *testing.T) {
 	persister := NewInmemoryStatePersister()
 	exe1 := uuid.New()
-	err := persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 1}})
+	err := persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 1}, nil})
 	require.NoError(t, err)
 
-	err = persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 100}})
+	err = persister.PersistState(context.Background(), &PersistedState{exe1, "preflight-1", preflight_kit_api.PreflightState{"test": 100}, nil})
 	require.NoError(t, err)
 
 	executionIds, err := persister.GetExecutionIds(context.Background())
@@ -72,3 +72,9 @@ func TestInmemoryStatePersister_should_update_existing_values(t *testing.T) {
 	require.Equal(t, "preflight-1", state.PreflightActionId)
 	require.Equal(t, 100, state.State["test"])
 }
+
+func TestInmemoryStatePersister_is_healthy(t *testing.T) {
+	checker, ok := NewInmemoryStatePersister().(HealthChecker)
+	require.True(t, ok)
+	require.NoError(t, checker.Health(context.Background()))
+}
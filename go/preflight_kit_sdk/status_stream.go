@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+const (
+	// streamEventStatus carries a StatusResult.
+	streamEventStatus = "status"
+	// streamEventEnd carries a StatusStreamEnd. The stream is closed afterward.
+	streamEventEnd = "end"
+
+	streamEndCompleted = "completed"
+	streamEndCanceled  = "canceled"
+
+	statusStreamBuffer = 16
+)
+
+var (
+	statusStreams = newStreamHub()
+	// statusStreamKeepAlive is the interval of the comments keeping idle streams open.
+	statusStreamKeepAlive = 15 * time.Second
+)
+
+// StatusStreamEnd is the data of the last event of a status stream.
+type StatusStreamEnd struct {
+	// Reason is "completed", if the execution reached a terminal state, or "canceled".
+	Reason string `json:"reason"`
+	// Detail explains a cancellation, e.g. "heartbeat timeout".
+	Detail string `json:"detail,omitempty"`
+}
+
+type streamEvent struct {
+	name string
+	data any
+}
+
+// streamHub fans the status results of executions out to the subscribed streams.
+type streamHub struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan streamEvent]struct{}
+	// ended executions, so streams opened afterward end right away. They are forgotten after stopEventTTL.
+	ended map[uuid.UUID]endedStream
+}
+
+type endedStream struct {
+	end       StatusStreamEnd
+	timestamp time.Time
+}
+
+func newStreamHub() *streamHub {
+	return &streamHub{
+		subscribers: make(map[uuid.UUID]map[chan streamEvent]struct{}),
+		ended:       make(map[uuid.UUID]endedStream),
+	}
+}
+
+// subscribe opens a stream of the execution. If the execution has already ended, the stream only carries the end event.
+func (h *streamHub) subscribe(preflightActionExecutionId uuid.UUID) (events <-chan streamEvent, unsubscribe func(), ended bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneEnded(currentClock().Now())
+	ch := make(chan streamEvent, statusStreamBuffer)
+	if ended, ok := h.ended[preflightActionExecutionId]; ok {
+		ch <- streamEvent{name: streamEventEnd, data: ended.end}
+		close(ch)
+		return ch, func() {}, true
+	}
+	if h.subscribers[preflightActionExecutionId] == nil {
+		h.subscribers[preflightActionExecutionId] = make(map[chan streamEvent]struct{})
+	}
+	h.subscribers[preflightActionExecutionId][ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[preflightActionExecutionId][ch]; ok {
+			delete(h.subscribers[preflightActionExecutionId], ch)
+			if len(h.subscribers[preflightActionExecutionId]) == 0 {
+				delete(h.subscribers, preflightActionExecutionId)
+			}
+			close(ch)
+		}
+	}, false
+}
+
+// publish sends a status result to the streams of the execution. Streams that can't keep up miss the result.
+func (h *streamHub) publish(preflightActionExecutionId uuid.UUID, result preflight_kit_api.StatusResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[preflightActionExecutionId] {
+		select {
+		case ch <- streamEvent{name: streamEventStatus, data: result}:
+		default:
+			log.Debug().
+				Str("preflightActionExecutionId", preflightActionExecutionId.String()).
+				Msg("status stream is too slow, dropping status")
+		}
+	}
+}
+
+// end closes the streams of the execution. The end event is sent to every stream with room for it; the others notice
+// the closed channel.
+func (h *streamHub) end(preflightActionExecutionId uuid.UUID, reason, detail string) {
+	now := currentClock().Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneEnded(now)
+	end := StatusStreamEnd{Reason: reason, Detail: detail}
+	if _, ok := h.ended[preflightActionExecutionId]; !ok {
+		h.ended[preflightActionExecutionId] = endedStream{end: end, timestamp: now}
+	}
+	for ch := range h.subscribers[preflightActionExecutionId] {
+		select {
+		case ch <- streamEvent{name: streamEventEnd, data: end}:
+		default:
+		}
+		close(ch)
+	}
+	delete(h.subscribers, preflightActionExecutionId)
+}
+
+// begin forgets an earlier end of the execution, e.g. when it is started again.
+func (h *streamHub) begin(preflightActionExecutionId uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.ended, preflightActionExecutionId)
+}
+
+// pruneEnded forgets the executions which ended longer than stopEventTTL ago. The caller must hold mu.
+func (h *streamHub) pruneEnded(now time.Time) {
+	for preflightActionExecutionId, ended := range h.ended {
+		if now.Sub(ended.timestamp) > stopEventTTL {
+			delete(h.ended, preflightActionExecutionId)
+		}
+	}
+}
+
+// WithStatusStream serves a Server-Sent Events stream of the status results of an execution on
+// GET <root path>/executions/{executionId}/status-stream, e.g. to watch a preflight's progress live. Each status call
+// and each [CompletePreflight] emits a "status" event with the StatusResult. The stream ends with an "end" event
+// carrying a [StatusStreamEnd] once the execution has completed, failed or been canceled, right away for executions
+// which have already ended. Streams of unknown executions are answered with 404.
+func WithStatusStream() PreflightOption {
+	return func(o *preflightOptions) {
+		o.statusStream = true
+	}
+}
+
+func (a *preflightHttpAdapter[T]) statusStreamPath() string {
+	return a.rootPath + "/executions/{executionId}/status-stream"
+}
+
+// publishStatus feeds the status streams of an execution and ends them, once the result is terminal.
+func publishStatus(preflightActionExecutionId uuid.UUID, result preflight_kit_api.StatusResult) {
+	statusStreams.publish(preflightActionExecutionId, result)
+	if result.Completed || result.Error != nil {
+		statusStreams.end(preflightActionExecutionId, streamEndCompleted, "")
+	}
+}
+
+func (a *preflightHttpAdapter[T]) handleStatusStream(w http.ResponseWriter, r *http.Request) {
+	id, failure := executionIdFromPath(r)
+	if failure != nil {
+		writeResponse(w, *failure)
+		return
+	}
+
+	// subscribe before looking the execution up, so it can't end unnoticed in between
+	events, unsubscribe, ended := statusStreams.subscribe(id)
+	defer unsubscribe()
+	if !ended && !a.knowsExecution(r.Context(), id) {
+		writeResponse(w, *errorResponse(http.StatusNotFound, "Execution not found.", nil))
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	// a compressing response writer only sends the headers along with content, so the stream opens with a comment
+	if _, err := fmt.Fprint(w, ": open\n\n"); err != nil {
+		return
+	}
+	if err := controller.Flush(); err != nil {
+		log.Warn().Err(err).Msg("Status streams require a flushable response writer.")
+		return
+	}
+
+	for {
+		keepAlive := currentClock().After(statusStreamKeepAlive)
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			if err := writeStreamEvent(w, event); err != nil {
+				log.Debug().Err(err).Str("preflightActionExecutionId", id.String()).Msg("Failed to write status stream event.")
+				return
+			}
+			if event.name == streamEventEnd {
+				_ = controller.Flush()
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// knowsExecution reports whether the execution is active or queued. After a restart, the executions are only known by
+// their persisted state.
+func (a *preflightHttpAdapter[T]) knowsExecution(ctx context.Context, preflightActionExecutionId uuid.UUID) bool {
+	if admission.tracks(preflightActionExecutionId) {
+		return true
+	}
+	if !a.persistsState() {
+		return false
+	}
+	_, err := statePersister.GetState(ctx, preflightActionExecutionId)
+	return err == nil
+}
+
+func writeStreamEvent(w http.ResponseWriter, event streamEvent) error {
+	data, err := json.Marshal(event.data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, data)
+	return err
+}
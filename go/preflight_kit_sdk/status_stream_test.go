@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// openStatusStream opens the status stream of an execution and returns its events, until the stream is closed.
+func openStatusStream(t *testing.T, server *httptest.Server, path string) <-chan sseEvent {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	response, err := server.Client().Do(request)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 10)
+	go func() {
+		defer close(events)
+		defer response.Body.Close()
+		var event sseEvent
+		scanner := bufio.NewScanner(response.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && event.name != "":
+				events <- event
+				event = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return event
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no event received")
+		return sseEvent{}
+	}
+}
+
+func requireClosed(t *testing.T, events <-chan sseEvent) {
+	select {
+	case _, ok := <-events:
+		require.False(t, ok, "stream not closed")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "stream not closed")
+	}
+}
+
+// waitForSubscriber waits until the stream has subscribed, as events published earlier aren't streamed.
+func waitForSubscriber(t *testing.T, executionId uuid.UUID) {
+	require.Eventually(t, func() bool {
+		statusStreams.mu.Lock()
+		defer statusStreams.mu.Unlock()
+		return len(statusStreams.subscribers[executionId]) > 0
+	}, 5*time.Second, time.Millisecond)
+}
+
+func newStreamingAdapter(t *testing.T) (*preflightHttpAdapter[ExampleState], *httptest.Server, chan Call) {
+	calls := make(chan Call, 10)
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(calls), WithStatusStream())
+	return adapter, serveRoutes(t, adapter), calls
+}
+
+// serveRoutes serves the routes of the adapter wrapped like registered ones, i.e. with compression, authentication and
+// request timeouts.
+func serveRoutes(t *testing.T, adapter *preflightHttpAdapter[ExampleState]) *httptest.Server {
+	mux := http.NewServeMux()
+	for _, r := range adapter.routes() {
+		mux.Handle(r.method+" "+r.path, wrapHttpHandler(r.handler))
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_status_stream_ends_with_completion(t *testing.T) {
+	adapter, server, _ := newStreamingAdapter(t)
+	executionId := uuid.New()
+	startResult := startExecution(t, adapter, executionId)
+	events := openStatusStream(t, server, "/ExamplePreflightId/executions/"+executionId.String()+"/status-stream")
+	waitForSubscriber(t, executionId)
+
+	statusExecution(t, adapter, executionId, startResult.State)
+	event := nextEvent(t, events)
+	assert.Equal(t, streamEventStatus, event.name)
+	var result preflight_kit_api.StatusResult
+	require.NoError(t, json.Unmarshal([]byte(event.data), &result))
+	assert.False(t, result.Completed)
+	assert.Equal(t, "Status", (*result.State)["TestStep"])
+
+	err := CompletePreflight(context.Background(), executionId, preflight_kit_api.StatusResult{
+		Summary: &preflight_kit_api.Summary{Level: preflight_kit_api.SummaryLevelInfo, Text: "done"},
+	})
+	assert.ErrorIs(t, err, ErrNoCallback, "the stream is fed without a callback as well")
+	event = nextEvent(t, events)
+	require.NoError(t, json.Unmarshal([]byte(event.data), &result))
+	assert.True(t, result.Completed)
+	assert.Equal(t, "done", result.Summary.Text)
+
+	event = nextEvent(t, events)
+	assert.Equal(t, streamEventEnd, event.name)
+	assert.JSONEq(t, `{"reason":"completed"}`, event.data)
+	requireClosed(t, events)
+
+	cancelExecution(t, adapter, executionId, startResult.State)
+}
+
+func Test_status_stream_ends_with_cancellation(t *testing.T) {
+	adapter, server, _ := newStreamingAdapter(t)
+	executionId := uuid.New()
+	startResult := startExecution(t, adapter, executionId)
+	events := openStatusStream(t, server, "/ExamplePreflightId/executions/"+executionId.String()+"/status-stream")
+	waitForSubscriber(t, executionId)
+
+	cancelExecution(t, adapter, executionId, startResult.State)
+	event := nextEvent(t, events)
+	assert.Equal(t, streamEventEnd, event.name)
+	assert.JSONEq(t, `{"reason":"canceled","detail":"canceled by agent"}`, event.data)
+	requireClosed(t, events)
+}
+
+func Test_status_stream_of_unknown_execution(t *testing.T) {
+	_, server, _ := newStreamingAdapter(t)
+
+	response, err := server.Client().Get(server.URL + "/ExamplePreflightId/executions/" + uuid.NewString() + "/status-stream")
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response, err = server.Client().Get(server.URL + "/ExamplePreflightId/executions/invalid/status-stream")
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func Test_status_stream_of_an_ended_execution(t *testing.T) {
+	adapter, server, _ := newStreamingAdapter(t)
+	executionId := uuid.New()
+	startResult := startExecution(t, adapter, executionId)
+	cancelExecution(t, adapter, executionId, startResult.State)
+
+	events := openStatusStream(t, server, "/ExamplePreflightId/executions/"+executionId.String()+"/status-stream")
+	event := nextEvent(t, events)
+	assert.Equal(t, streamEventEnd, event.name)
+	assert.JSONEq(t, `{"reason":"canceled","detail":"canceled by agent"}`, event.data)
+	requireClosed(t, events)
+}
+
+func Test_status_stream_without_persisted_state(t *testing.T) {
+	p := &noCancelPreflight{}
+	adapter := newPreflightHttpAdapter[ExampleState](p, WithStatusStream())
+	server := serveRoutes(t, adapter)
+	streamPath := func(executionId uuid.UUID) string {
+		return "/NoCancelPreflightId/executions/" + executionId.String() + "/status-stream"
+	}
+
+	response, err := server.Client().Get(server.URL + streamPath(uuid.New()))
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode, "unknown executions aren't streamed")
+
+	executionId := uuid.New()
+	startExecution(t, adapter, executionId)
+	events := openStatusStream(t, server, streamPath(executionId))
+	waitForSubscriber(t, executionId)
+	p.completed = true
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	assert.Equal(t, streamEventStatus, nextEvent(t, events).name)
+	assert.Equal(t, streamEventEnd, nextEvent(t, events).name)
+	requireClosed(t, events)
+
+	events = openStatusStream(t, server, streamPath(executionId))
+	event := nextEvent(t, events)
+	assert.Equal(t, streamEventEnd, event.name, "streams opened after the end end right away")
+	assert.JSONEq(t, `{"reason":"completed"}`, event.data)
+	requireClosed(t, events)
+}
+
+func Test_status_stream_is_optional(t *testing.T) {
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(nil))
+	for _, r := range adapter.routes() {
+		assert.NotEqual(t, "status stream", r.name)
+	}
+}
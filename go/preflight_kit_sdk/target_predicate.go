@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/extension-kit/extquery"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+)
+
+// Operators of a TargetAttributeKeyValuePredicateAO. Like the query language, an attribute is a set of values: EQUALS
+// matches if any value of the attribute equals any of the predicate's values, NOT_EQUALS if none does.
+const (
+	TargetAttributeEquals                = "EQUALS"
+	TargetAttributeNotEquals             = "NOT_EQUALS"
+	TargetAttributeContains              = "CONTAINS"
+	TargetAttributeNotContains           = "NOT_CONTAINS"
+	TargetAttributeEqualsIgnoreCase      = "EQUALS_IGNORE_CASE"
+	TargetAttributeNotEqualsIgnoreCase   = "NOT_EQUALS_IGNORE_CASE"
+	TargetAttributeContainsIgnoreCase    = "CONTAINS_IGNORE_CASE"
+	TargetAttributeNotContainsIgnoreCase = "NOT_CONTAINS_IGNORE_CASE"
+)
+
+// ErrUnknownAgentId is returned when an agent id predicate is matched against a target without agent id.
+var ErrUnknownAgentId = errors.New("agent id of the target is unknown")
+
+// PredicateTarget is a target as seen by a target predicate.
+type PredicateTarget struct {
+	Name string
+	Type string
+	// AgentId of the agent discovering the target, the zero UUID if unknown.
+	AgentId    uuid.UUID
+	Attributes []preflight_kit_api.AttributeAO
+}
+
+// PredicateTargetOf returns the target of a target execution, e.g. of an experiment execution's step. Target executions
+// don't carry the id of the discovering agent: set AgentId if you know it, otherwise agent id predicates fail to match
+// the target with [ErrUnknownAgentId].
+func PredicateTargetOf(target preflight_kit_api.TargetExecutionAO) PredicateTarget {
+	result := PredicateTarget{}
+	if target.Name != nil {
+		result.Name = *target.Name
+	}
+	if target.Type != nil {
+		result.Type = *target.Type
+	}
+	if target.Attributes != nil {
+		result.Attributes = *target.Attributes
+	}
+	return result
+}
+
+// values returns the values of an attribute, nil if the target doesn't have it.
+func (t PredicateTarget) values(key string) []string {
+	var values []string
+	for _, attribute := range t.Attributes {
+		if attribute.Key == key {
+			values = append(values, attribute.Value)
+		}
+	}
+	return values
+}
+
+// TargetMatcher reports whether a target matches a compiled target predicate. It fails with [ErrUnknownAgentId] if the
+// predicate depends on the agent id of a target without one.
+type TargetMatcher func(target PredicateTarget) (bool, error)
+
+// CompileTargetPredicate compiles a target predicate, e.g. the predicate of a BlastRadiusAO, to decide which targets an
+// attack could hit. A nil predicate matches every target. Compile a predicate once and reuse the matcher, it is safe for
+// concurrent use.
+//
+// Attribute predicates are told apart by their operator and match like the query language of the platform: a value
+// predicate over an empty or missing list of values matches no target for the positive operators and every target for
+// the negated ones, and a count predicate doesn't match targets without the attribute. Attribute key predicates take
+// the operators of presence predicates. Unknown predicates and operators fail the compilation.
+func CompileTargetPredicate(predicate *preflight_kit_api.TargetPredicateAO) (TargetMatcher, error) {
+	if predicate == nil {
+		return func(PredicateTarget) (bool, error) { return true, nil }, nil
+	}
+
+	raw, err := predicate.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("target predicate is not an object: %w", err)
+	}
+	has := func(field string) bool {
+		_, ok := fields[field]
+		return ok
+	}
+
+	switch {
+	case has("not"):
+		negation, err := predicate.AsNegationTargetPredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		if negation.Not == nil {
+			return nil, errors.New("negation predicate without predicate")
+		}
+		inner, err := CompileTargetPredicate(negation.Not)
+		if err != nil {
+			return nil, fmt.Errorf("negated predicate: %w", err)
+		}
+		return func(target PredicateTarget) (bool, error) {
+			matches, err := inner(target)
+			if err != nil {
+				return false, err
+			}
+			return !matches, nil
+		}, nil
+
+	case has("query"):
+		p, err := predicate.AsQueryLanguagePredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		query, err := extquery.Parse(deref(p.Query))
+		if err != nil {
+			return nil, err
+		}
+		return func(target PredicateTarget) (bool, error) {
+			return query.Matches(extquery.AttributesFunc(target.values)), nil
+		}, nil
+
+	case has("agentId"):
+		p, err := predicate.AsTargetAgentIdPredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		if p.AgentId == nil {
+			return nil, errors.New("agent id predicate without agent id")
+		}
+		agentId := *p.AgentId
+		return func(target PredicateTarget) (bool, error) {
+			if target.AgentId == uuid.Nil {
+				return false, ErrUnknownAgentId
+			}
+			return target.AgentId == agentId, nil
+		}, nil
+
+	case has("valueCountOperator"):
+		p, err := predicate.AsTargetAttributeKeyCountPredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		return compileCountPredicate(p)
+
+	case has("presenceOperator"):
+		p, err := predicate.AsTargetAttributeKeyPresencePredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		return compilePresencePredicate(deref(p.Key), string(derefOr(p.PresenceOperator, "")))
+
+	case has("operator"), has("values"):
+		// value and attribute key predicates share the operator field, a missing list of values is an empty one
+		p, err := predicate.AsTargetAttributeKeyValuePredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		if isPresenceOperator(deref(p.Operator)) {
+			return compilePresencePredicate(deref(p.Key), deref(p.Operator))
+		}
+		return compileValuePredicate(p)
+
+	case has("name"):
+		p, err := predicate.AsTargetNamePredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		name := deref(p.Name)
+		return func(target PredicateTarget) (bool, error) { return target.Name == name, nil }, nil
+
+	case has("types"):
+		p, err := predicate.AsTargetTypePredicateAO()
+		if err != nil {
+			return nil, err
+		}
+		types := derefOr(p.Types, nil)
+		return func(target PredicateTarget) (bool, error) { return slices.Contains(types, target.Type), nil }, nil
+	}
+	return nil, fmt.Errorf("unknown target predicate %s", raw)
+}
+
+// MatchesTargetPredicate compiles the predicate and matches the target. See [CompileTargetPredicate].
+func MatchesTargetPredicate(predicate *preflight_kit_api.TargetPredicateAO, target PredicateTarget) (bool, error) {
+	matches, err := CompileTargetPredicate(predicate)
+	if err != nil {
+		return false, err
+	}
+	return matches(target)
+}
+
+func compileCountPredicate(p preflight_kit_api.TargetAttributeKeyCountPredicateAO) (TargetMatcher, error) {
+	key := deref(p.Key)
+	want, err := strconv.Atoi(deref(p.Value))
+	if err != nil {
+		return nil, fmt.Errorf("count of attribute %q is not an integer: %w", key, err)
+	}
+	var compare func(count int) bool
+	switch derefOr(p.ValueCountOperator, "") {
+	case preflight_kit_api.EQUAL:
+		compare = func(count int) bool { return count == want }
+	case preflight_kit_api.NOTEQUAL:
+		compare = func(count int) bool { return count != want }
+	case preflight_kit_api.GREATERTHAN:
+		compare = func(count int) bool { return count > want }
+	case preflight_kit_api.GREATERTHANOREQUAL:
+		compare = func(count int) bool { return count >= want }
+	case preflight_kit_api.LESSTHAN:
+		compare = func(count int) bool { return count < want }
+	case preflight_kit_api.LESSTHANOREQUAL:
+		compare = func(count int) bool { return count <= want }
+	default:
+		return nil, fmt.Errorf("unknown count operator %q of attribute %q", derefOr(p.ValueCountOperator, ""), key)
+	}
+	return func(target PredicateTarget) (bool, error) {
+		// an absent attribute is not a count of zero
+		values := target.values(key)
+		return len(values) > 0 && compare(len(values)), nil
+	}, nil
+}
+
+func isPresenceOperator(operator string) bool {
+	switch preflight_kit_api.TargetAttributeKeyPresencePredicateAOPresenceOperator(operator) {
+	case preflight_kit_api.PRESENT, preflight_kit_api.NOTPRESENT:
+		return true
+	}
+	return false
+}
+
+func compilePresencePredicate(key, operator string) (TargetMatcher, error) {
+	var present bool
+	switch preflight_kit_api.TargetAttributeKeyPresencePredicateAOPresenceOperator(operator) {
+	case preflight_kit_api.PRESENT:
+		present = true
+	case preflight_kit_api.NOTPRESENT:
+		present = false
+	default:
+		return nil, fmt.Errorf("unknown presence operator %q of attribute %q", operator, key)
+	}
+	return func(target PredicateTarget) (bool, error) { return (len(target.values(key)) > 0) == present, nil }, nil
+}
+
+func compileValuePredicate(p preflight_kit_api.TargetAttributeKeyValuePredicateAO) (TargetMatcher, error) {
+	key, operator, values := deref(p.Key), deref(p.Operator), derefOr(p.Values, nil)
+	var match func(attributeValue, value string) bool
+	negated := strings.HasPrefix(operator, "NOT_")
+	switch operator {
+	case TargetAttributeEquals, TargetAttributeNotEquals:
+		match = func(attributeValue, value string) bool { return attributeValue == value }
+	case TargetAttributeContains, TargetAttributeNotContains:
+		match = strings.Contains
+	case TargetAttributeEqualsIgnoreCase, TargetAttributeNotEqualsIgnoreCase:
+		match = strings.EqualFold
+	case TargetAttributeContainsIgnoreCase, TargetAttributeNotContainsIgnoreCase:
+		match = func(attributeValue, value string) bool {
+			return strings.Contains(strings.ToLower(attributeValue), strings.ToLower(value))
+		}
+	default:
+		return nil, fmt.Errorf("unknown operator %q of attribute %q", operator, key)
+	}
+	return func(target PredicateTarget) (bool, error) {
+		// the positive answer is negated once for the NOT_ operators: NOT_EQUALS means that no value equals
+		for _, attributeValue := range target.values(key) {
+			for _, value := range values {
+				if match(attributeValue, value) {
+					return !negated, nil
+				}
+			}
+		}
+		return negated, nil
+	}, nil
+}
+
+func deref(s *string) string {
+	return derefOr(s, "")
+}
+
+func derefOr[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
+}
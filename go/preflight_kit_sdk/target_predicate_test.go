@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func targetPredicate(t *testing.T, predicate string) *preflight_kit_api.TargetPredicateAO {
+	var result preflight_kit_api.TargetPredicateAO
+	require.NoError(t, json.Unmarshal([]byte(predicate), &result))
+	return &result
+}
+
+func Test_CompileTargetPredicate(t *testing.T) {
+	agentId := uuid.MustParse("4c9e6e5b-5e3a-4a9b-9d1f-3f0c6c1e2a7d")
+	target := PredicateTarget{
+		Name:    "checkout-7d4f",
+		Type:    "com.steadybit.extension_kubernetes.kubernetes-pod",
+		AgentId: agentId,
+		Attributes: []preflight_kit_api.AttributeAO{
+			{Key: "k8s.namespace", Value: "shop"},
+			{Key: "k8s.label.app", Value: "Checkout"},
+			{Key: "k8s.container.name", Value: "checkout"},
+			{Key: "k8s.container.name", Value: "istio-proxy"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		predicate string
+		matches   bool
+	}{
+		{"name", `{"name": "checkout-7d4f"}`, true},
+		{"other name", `{"name": "checkout"}`, false},
+		{"type", `{"types": ["com.steadybit.extension_kubernetes.kubernetes-deployment", "com.steadybit.extension_kubernetes.kubernetes-pod"]}`, true},
+		{"other type", `{"types": ["com.steadybit.extension_host.host"]}`, false},
+		{"agent id", `{"agentId": "4c9e6e5b-5e3a-4a9b-9d1f-3f0c6c1e2a7d"}`, true},
+		{"other agent id", `{"agentId": "00000000-0000-0000-0000-000000000001"}`, false},
+
+		{"EQUALS", `{"key": "k8s.namespace", "operator": "EQUALS", "values": ["demo", "shop"]}`, true},
+		{"EQUALS any value", `{"key": "k8s.container.name", "operator": "EQUALS", "values": ["istio-proxy"]}`, true},
+		{"EQUALS absent", `{"key": "k8s.cluster-name", "operator": "EQUALS", "values": ["prod"]}`, false},
+		{"EQUALS without values", `{"key": "k8s.namespace", "operator": "EQUALS", "values": []}`, false},
+		{"EQUALS with missing values", `{"key": "k8s.namespace", "operator": "EQUALS"}`, false},
+		{"NOT_EQUALS", `{"key": "k8s.namespace", "operator": "NOT_EQUALS", "values": ["demo"]}`, true},
+		{"NOT_EQUALS any value", `{"key": "k8s.container.name", "operator": "NOT_EQUALS", "values": ["istio-proxy"]}`, false},
+		{"NOT_EQUALS absent", `{"key": "k8s.cluster-name", "operator": "NOT_EQUALS", "values": ["prod"]}`, true},
+		{"NOT_EQUALS without values", `{"key": "k8s.namespace", "operator": "NOT_EQUALS", "values": []}`, true},
+		{"NOT_EQUALS with missing values", `{"key": "k8s.namespace", "operator": "NOT_EQUALS"}`, true},
+		{"CONTAINS", `{"key": "k8s.container.name", "operator": "CONTAINS", "values": ["proxy"]}`, true},
+		{"NOT_CONTAINS", `{"key": "k8s.container.name", "operator": "NOT_CONTAINS", "values": ["proxy"]}`, false},
+		{"EQUALS case sensitive", `{"key": "k8s.label.app", "operator": "EQUALS", "values": ["checkout"]}`, false},
+		{"EQUALS_IGNORE_CASE", `{"key": "k8s.label.app", "operator": "EQUALS_IGNORE_CASE", "values": ["checkout"]}`, true},
+		{"NOT_EQUALS_IGNORE_CASE", `{"key": "k8s.label.app", "operator": "NOT_EQUALS_IGNORE_CASE", "values": ["checkout"]}`, false},
+		{"CONTAINS_IGNORE_CASE", `{"key": "k8s.label.app", "operator": "CONTAINS_IGNORE_CASE", "values": ["CHECK"]}`, true},
+		{"NOT_CONTAINS_IGNORE_CASE", `{"key": "k8s.label.app", "operator": "NOT_CONTAINS_IGNORE_CASE", "values": ["CHECK"]}`, false},
+
+		{"PRESENT", `{"key": "k8s.namespace", "presenceOperator": "PRESENT"}`, true},
+		{"PRESENT absent", `{"key": "k8s.cluster-name", "presenceOperator": "PRESENT"}`, false},
+		{"NOT_PRESENT", `{"key": "k8s.cluster-name", "presenceOperator": "NOT_PRESENT"}`, true},
+		{"key predicate", `{"key": "k8s.namespace", "operator": "NOT_PRESENT"}`, false},
+
+		{"count EQUAL", `{"key": "k8s.container.name", "value": "2", "valueCountOperator": "EQUAL"}`, true},
+		{"count NOT_EQUAL", `{"key": "k8s.container.name", "value": "2", "valueCountOperator": "NOT_EQUAL"}`, false},
+		{"count GREATER_THAN", `{"key": "k8s.container.name", "value": "1", "valueCountOperator": "GREATER_THAN"}`, true},
+		{"count GREATER_THAN_OR_EQUAL", `{"key": "k8s.container.name", "value": "3", "valueCountOperator": "GREATER_THAN_OR_EQUAL"}`, false},
+		{"count LESS_THAN", `{"key": "k8s.container.name", "value": "2", "valueCountOperator": "LESS_THAN"}`, false},
+		{"count LESS_THAN_OR_EQUAL", `{"key": "k8s.container.name", "value": "2", "valueCountOperator": "LESS_THAN_OR_EQUAL"}`, true},
+		{"count of absent attribute", `{"key": "k8s.cluster-name", "value": "1", "valueCountOperator": "LESS_THAN"}`, false},
+
+		{"query", `{"query": "k8s.namespace=\"shop\" AND NOT k8s.container.name=sidecar"}`, true},
+		{"query not matching", `{"query": "k8s.label.app=checkout"}`, false},
+		{"empty query", `{"query": ""}`, true},
+		{"negation", `{"not": {"name": "checkout-7d4f"}}`, false},
+		{"nested negation", `{"not": {"not": {"key": "k8s.namespace", "presenceOperator": "PRESENT"}}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := MatchesTargetPredicate(targetPredicate(t, tt.predicate), target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+}
+
+func Test_CompileTargetPredicate_without_predicate(t *testing.T) {
+	matches, err := MatchesTargetPredicate(nil, PredicateTarget{})
+	require.NoError(t, err)
+	assert.True(t, matches)
+}
+
+func Test_CompileTargetPredicate_rejects_invalid_predicates(t *testing.T) {
+	for _, predicate := range []string{
+		`{}`,
+		`{"not": null}`,
+		`{"not": {"query": "a="}}`,
+		`{"query": "a="}`,
+		`{"key": "a", "operator": "MATCHES", "values": ["b"]}`,
+		`{"key": "a", "presenceOperator": "EXISTS"}`,
+		`{"key": "a", "values": ["b"]}`,
+		`{"key": "a", "value": "many", "valueCountOperator": "EQUAL"}`,
+		`{"key": "a", "value": "1", "valueCountOperator": "MORE"}`,
+	} {
+		_, err := CompileTargetPredicate(targetPredicate(t, predicate))
+		assert.Error(t, err, predicate)
+	}
+}
+
+func Test_CompileTargetPredicate_with_unknown_agent_id(t *testing.T) {
+	for _, predicate := range []string{
+		`{"agentId": "4c9e6e5b-5e3a-4a9b-9d1f-3f0c6c1e2a7d"}`,
+		`{"not": {"agentId": "4c9e6e5b-5e3a-4a9b-9d1f-3f0c6c1e2a7d"}}`,
+	} {
+		matches, err := MatchesTargetPredicate(targetPredicate(t, predicate), PredicateTarget{Name: "checkout-7d4f"})
+		assert.ErrorIs(t, err, ErrUnknownAgentId, predicate)
+		assert.False(t, matches, predicate)
+	}
+}
+
+func Test_PredicateTargetOf(t *testing.T) {
+	target := PredicateTargetOf(preflight_kit_api.TargetExecutionAO{
+		Name:       extutil.Ptr("checkout-7d4f"),
+		Type:       extutil.Ptr("com.steadybit.extension_kubernetes.kubernetes-pod"),
+		Attributes: &[]preflight_kit_api.AttributeAO{{Key: "k8s.namespace", Value: "shop"}},
+	})
+
+	matches, err := MatchesTargetPredicate(targetPredicate(t, `{"query": "k8s.namespace=shop"}`), target)
+	require.NoError(t, err)
+	assert.True(t, matches)
+	assert.Equal(t, "checkout-7d4f", target.Name)
+	assert.Equal(t, "com.steadybit.extension_kubernetes.kubernetes-pod", target.Type)
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk/v2/state_persister"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/steadybit/preflight-kit/go/preflight_kit_sdk"
+
+const (
+	attributePreflightId   = attribute.Key("preflight.id")
+	attributeExecutionId   = attribute.Key("preflight.execution.id")
+	attributeOutcome       = attribute.Key("preflight.outcome")
+	attributeSummaryLevel  = attribute.Key("preflight.summary.level")
+	attributeCancelReason  = attribute.Key("preflight.cancel.reason")
+	attributeHttpStatus    = attribute.Key("http.response.status_code")
+	outcomeRunning         = "running"
+	outcomeCompleted       = "completed"
+	outcomeCanceled        = "canceled"
+	outcomeRequestRejected = "rejected"
+)
+
+var tracerProvider atomic.Pointer[trace.TracerProvider]
+
+// SetTracerProvider sets the provider for the spans of preflight calls. Defaults to the global tracer provider, which
+// the application configures via otel.SetTracerProvider.
+func SetTracerProvider(provider trace.TracerProvider) {
+	tracerProvider.Store(&provider)
+}
+
+func tracer() trace.Tracer {
+	if provider := tracerProvider.Load(); provider != nil {
+		return (*provider).Tracer(tracerName)
+	}
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+// startServerSpan starts the span of a call from the agent. The agent's W3C trace context becomes the parent.
+func (a *preflightHttpAdapter[T]) startServerSpan(r *http.Request, name string) (context.Context, trace.Span) {
+	ctx := propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributePreflightId.String(a.description.Id)),
+	)
+}
+
+func setExecutionId(ctx context.Context, preflightActionExecutionId uuid.UUID) {
+	trace.SpanFromContext(ctx).SetAttributes(attributeExecutionId.String(preflightActionExecutionId.String()))
+}
+
+// recordOutcome adds the outcome of a call to its span: the status of a reported error, or whether the preflight
+// is running, completed or canceled. It returns the outcome.
+func recordOutcome(span trace.Span, resp response, defaultOutcome string) string {
+	span.SetAttributes(attributeHttpStatus.Int(resp.status))
+	if resp.status >= http.StatusBadRequest {
+		span.SetAttributes(attributeOutcome.String(outcomeRequestRejected))
+		span.SetStatus(codes.Error, http.StatusText(resp.status))
+		return outcomeRequestRejected
+	}
+
+	var kitError *preflight_kit_api.PreflightKitError
+	var summary *preflight_kit_api.Summary
+	outcome := defaultOutcome
+	switch result := resp.body.(type) {
+	case *preflight_kit_api.StartResult:
+		kitError, summary = result.Error, result.Summary
+	case preflight_kit_api.StartResult:
+		kitError, summary = result.Error, result.Summary
+	case *preflight_kit_api.StatusResult:
+		kitError, summary = result.Error, result.Summary
+		if result.Completed {
+			outcome = outcomeCompleted
+		}
+	case preflight_kit_api.StatusResult:
+		kitError, summary = result.Error, result.Summary
+		if result.Completed {
+			outcome = outcomeCompleted
+		}
+	case *preflight_kit_api.CancelResult:
+		kitError, summary = result.Error, result.Summary
+	case preflight_kit_api.CancelResult:
+		kitError, summary = result.Error, result.Summary
+	}
+
+	if kitError != nil {
+		outcome = string(preflight_kit_api.Errored)
+		if kitError.Status != nil {
+			outcome = string(*kitError.Status)
+		}
+		span.SetStatus(codes.Error, kitError.Title)
+	}
+	span.SetAttributes(attributeOutcome.String(outcome))
+	if summary != nil {
+		span.SetAttributes(attributeSummaryLevel.String(string(summary.Level)))
+	}
+	return outcome
+}
+
+// tracingStatePersister adds spans to the operations of a state persister.
+type tracingStatePersister struct {
+	delegate state_persister.StatePersister
+}
+
+func (p tracingStatePersister) PersistState(ctx context.Context, state *state_persister.PersistedState) error {
+	ctx, span := tracer().Start(ctx, "preflight.state.persist", trace.WithAttributes(
+		attributePreflightId.String(state.PreflightActionId),
+		attributeExecutionId.String(state.PreflightActionExecutionId.String()),
+	))
+	defer span.End()
+	return recordError(span, p.delegate.PersistState(ctx, state))
+}
+
+func (p tracingStatePersister) GetExecutionIds(ctx context.Context) ([]uuid.UUID, error) {
+	ctx, span := tracer().Start(ctx, "preflight.state.list")
+	defer span.End()
+	ids, err := p.delegate.GetExecutionIds(ctx)
+	return ids, recordError(span, err)
+}
+
+func (p tracingStatePersister) GetState(ctx context.Context, executionId uuid.UUID) (*state_persister.PersistedState, error) {
+	ctx, span := tracer().Start(ctx, "preflight.state.get", trace.WithAttributes(attributeExecutionId.String(executionId.String())))
+	defer span.End()
+	state, err := p.delegate.GetState(ctx, executionId)
+	return state, recordError(span, err)
+}
+
+func (p tracingStatePersister) DeleteState(ctx context.Context, executionId uuid.UUID) error {
+	ctx, span := tracer().Start(ctx, "preflight.state.delete", trace.WithAttributes(attributeExecutionId.String(executionId.String())))
+	defer span.End()
+	return recordError(span, p.delegate.DeleteState(ctx, executionId))
+}
+
+// untraced returns the persister a tracingStatePersister delegates to, for calls too frequent to be traced.
+func untraced(persister state_persister.StatePersister) state_persister.StatePersister {
+	if tracing, ok := persister.(tracingStatePersister); ok {
+		return tracing.delegate
+	}
+	return persister
+}
+
+func recordError(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package preflight_kit_sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { tracerProvider.Store(nil) })
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func endedSpans(recorder *tracetest.SpanRecorder, name string) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func Test_tracing_of_preflight_calls(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	adapter := newPreflightHttpAdapter[ExampleState](NewExamplePreflight(make(chan Call, 10)))
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+
+	body, err := json.Marshal(preflight_kit_api.StartPreflightRequestBody{PreflightActionExecutionId: executionId})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, adapter.description.Start.Path, bytes.NewReader(body))
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	adapter.handleStart(httptest.NewRecorder(), request)
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+	cancelExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	starts := endedSpans(recorder, "preflight.start")
+	require.Len(t, starts, 1)
+	start := starts[0]
+	assert.Equal(t, trace.SpanKindServer, start.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", start.SpanContext().TraceID().String(), "the agent's trace is continued")
+	assert.Equal(t, "00f067aa0ba902b7", start.Parent().SpanID().String())
+	attributes := spanAttributes(start)
+	assert.Equal(t, "ExamplePreflightId", attributes[attributePreflightId].AsString())
+	assert.Equal(t, executionId.String(), attributes[attributeExecutionId].AsString())
+	assert.Equal(t, outcomeRunning, attributes[attributeOutcome].AsString())
+
+	persists := endedSpans(recorder, "preflight.state.persist")
+	require.NotEmpty(t, persists)
+	assert.Equal(t, start.SpanContext().SpanID(), persists[0].Parent().SpanID(), "persister operations are part of the call")
+
+	require.Len(t, endedSpans(recorder, "preflight.status"), 1)
+	cancels := endedSpans(recorder, "preflight.cancel")
+	require.Len(t, cancels, 1)
+	assert.Equal(t, outcomeCanceled, spanAttributes(cancels[0])[attributeOutcome].AsString())
+	require.NotEmpty(t, endedSpans(recorder, "preflight.state.delete"))
+}
+
+type failingStatusPreflight struct {
+	*ExamplePreflight
+}
+
+func (p *failingStatusPreflight) Status(_ context.Context, _ *ExampleState) (*preflight_kit_api.StatusResult, error) {
+	return &preflight_kit_api.StatusResult{Summary: &preflight_kit_api.Summary{Level: preflight_kit_api.SummaryLevelWarning, Text: "Check failed"}}, ToFailedError("Check failed.", nil)
+}
+
+func Test_tracing_records_failures(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	adapter := newPreflightHttpAdapter[ExampleState](&failingStatusPreflight{NewExamplePreflight(make(chan Call, 10))})
+	executionId := uuid.New()
+	t.Cleanup(func() { _ = statePersister.DeleteState(context.Background(), executionId) })
+
+	statusExecution(t, adapter, executionId, preflight_kit_api.PreflightState{})
+
+	statuses := endedSpans(recorder, "preflight.status")
+	require.Len(t, statuses, 1)
+	attributes := spanAttributes(statuses[0])
+	assert.Equal(t, string(preflight_kit_api.Failed), attributes[attributeOutcome].AsString())
+	assert.Equal(t, string(preflight_kit_api.SummaryLevelWarning), attributes[attributeSummaryLevel].AsString())
+	assert.Equal(t, codes.Error, statuses[0].Status().Code)
+	assert.Equal(t, "Check failed.", statuses[0].Status().Description)
+}
+
+func Test_tracing_of_heartbeat_cancellations(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	executionId := uuid.New()
+
+	CancelPreflight(context.Background(), executionId, "heartbeat timeout")
+
+	cancels := endedSpans(recorder, "preflight.cancel")
+	require.Len(t, cancels, 1)
+	assert.Equal(t, trace.SpanKindInternal, cancels[0].SpanKind())
+	assert.Equal(t, "heartbeat timeout", spanAttributes(cancels[0])[attributeCancelReason].AsString())
+	gets := endedSpans(recorder, "preflight.state.get")
+	require.Len(t, gets, 1)
+	assert.Equal(t, codes.Error, gets[0].Status().Code, "the state of an unknown execution can't be loaded")
+}